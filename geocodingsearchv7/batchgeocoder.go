@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+
+	"go.einride.tech/here/internal/ratelimit"
 )
 
 // BatchGeocoderUpload allows batch forward geocoding of addresses.
@@ -43,7 +45,7 @@ func (s *BatchGeocodingService) BatchGeocoderUpload(
 		body = queryBody(req.Queries)
 	}
 
-	r, err := s.Client.NewRequest(ctx, u, http.MethodPost, values.Encode(), body)
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodPost, values.Encode(), body)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create post request: %v", err)
 	}
@@ -81,7 +83,7 @@ func (s *BatchGeocodingService) BatchReverseGeocoderUpload(
 
 	body := geoPositionBody(req.GeoPositions)
 
-	r, err := s.Client.NewRequest(ctx, u, http.MethodPost, values.Encode(), body)
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodPost, values.Encode(), body)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create post request: %v", err)
 	}
@@ -111,7 +113,7 @@ func (s *BatchGeocodingService) BatchGeocoderStatus(
 	values := make(url.Values)
 	values.Add("action", "status")
 
-	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodGet, values.Encode(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create get request: %v", err)
 	}
@@ -140,7 +142,7 @@ func (s *BatchGeocodingService) BatchGeocoderDownload(
 	if err != nil {
 		return err
 	}
-	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, "", nil)
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodGet, "", nil)
 	if err != nil {
 		return fmt.Errorf("unable to create get request: %v", err)
 	}
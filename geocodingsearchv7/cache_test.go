@@ -0,0 +1,82 @@
+package geocodingsearchv7_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"go.einride.tech/here/internal/httpmock"
+	"gotest.tools/v3/assert"
+)
+
+func TestGeocodingService_Geocoding_Cache(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name             string
+		ttl              time.Duration
+		expectedRequests int
+	}{
+		{name: "cached", ttl: time.Hour, expectedRequests: 1},
+		{name: "no ttl", expectedRequests: 2},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock := &httpmock.Client{
+				Body: func(*http.Request) string {
+					return `{"items":[]}`
+				},
+			}
+			client := geocodingsearchv7.NewClient(mock)
+			client.Cache = geocodingsearchv7.NewLRUCache(10)
+			client.Geocoding.CacheTTL = tt.ttl
+			q := "Regeringsgatan 65, Stockholm"
+			for i := 0; i < 2; i++ {
+				resp, err := client.Geocoding.Geocoding(context.Background(), &geocodingsearchv7.GeocodingRequest{Q: &q})
+				assert.NilError(t, err)
+				assert.Assert(t, resp != nil)
+			}
+			assert.Equal(t, tt.expectedRequests, len(mock.Bodies()))
+		})
+	}
+}
+
+func TestClient_Offline(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cache, err := geocodingsearchv7.NewDiskCache(t.TempDir())
+	assert.NilError(t, err)
+	cache.MapVersion = "2021.1"
+	q := "Regeringsgatan 65, Stockholm"
+	// Record the response without serving it online.
+	mock := &httpmock.Client{
+		Body: func(*http.Request) string {
+			return `{"items":[]}`
+		},
+	}
+	client := geocodingsearchv7.NewClient(mock)
+	client.Cache = cache
+	_, err = client.Geocoding.Geocoding(ctx, &geocodingsearchv7.GeocodingRequest{Q: &q})
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(mock.Bodies()))
+	// Serve the recorded response offline.
+	cache.KeepExpired = true
+	offlineMock := &httpmock.Client{}
+	offline := geocodingsearchv7.NewClient(offlineMock)
+	offline.Cache = cache
+	offline.Offline = true
+	resp, err := offline.Geocoding.Geocoding(ctx, &geocodingsearchv7.GeocodingRequest{Q: &q})
+	assert.NilError(t, err)
+	assert.Assert(t, resp != nil)
+	other := "Vasagatan 1, Stockholm"
+	_, err = offline.Geocoding.Geocoding(ctx, &geocodingsearchv7.GeocodingRequest{Q: &other})
+	assert.Assert(t, errors.Is(err, geocodingsearchv7.ErrOffline))
+	_, err = offline.BatchGeocoding.BatchGeocoderUpload(ctx, &geocodingsearchv7.BatchGeocoderUploadRequest{
+		Queries: []*geocodingsearchv7.QueryString{{RecID: "1", Query: q}},
+	})
+	assert.ErrorContains(t, err, geocodingsearchv7.ErrOffline.Error())
+	assert.Equal(t, 0, len(offlineMock.Bodies()))
+}
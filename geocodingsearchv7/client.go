@@ -9,6 +9,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
+
+	"go.einride.tech/here/internal/cache"
+	"go.einride.tech/here/internal/ratelimit"
+	"go.einride.tech/here/internal/retry"
 )
 
 const (
@@ -34,6 +39,19 @@ type Client struct {
 
 	UserAgent string
 
+	// RetryPolicy configures how requests failing with a transient error are retried. Requests are not retried by
+	// default.
+	RetryPolicy RetryPolicy
+
+	// Cache stores responses, if set. Only responses of GeocodingService.Geocoding and
+	// ReverseGeocodingService.ReverseGeocoding are cached, and served for the CacheTTL of the service.
+	Cache Cache
+
+	// Offline serves responses only from the Cache, and fails with ErrOffline instead of sending a request.
+	// Only GeocodingService.Geocoding and ReverseGeocodingService.ReverseGeocoding can be used offline, all other
+	// requests fail.
+	Offline bool
+
 	// Geocoding service
 	Geocoding *GeocodingService
 	// ReverseGeocoding service
@@ -46,6 +64,12 @@ type service struct {
 	// URL for service API requests
 	URL    *url.URL
 	Client *Client
+	// RateLimiter limits the rate of requests to the service, if set.
+	RateLimiter *RateLimiter
+	// CacheTTL is how long responses of the service are stored in the Cache of the Client, for the methods which
+	// support caching. Responses are not served from the cache if zero, but may be stored for offline use, see
+	// Client.Offline.
+	CacheTTL time.Duration
 }
 
 // A ResponseError reports the error caused by an API request.
@@ -121,8 +145,10 @@ func (c *Client) NewRequest(
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
+// Requests with idempotent methods are retried according to the RetryPolicy of the client. Each attempt waits for
+// the RateLimiter of the service that created the request, if any.
 func (c *Client) Do(req *http.Request, v interface{}) error {
-	resp, err := c.client.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return err
 	}
@@ -177,8 +203,10 @@ func checkResponse(r *http.Response) error {
 // DoXML sends an API request and returns the API response. The API response is XML decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
+// Requests with idempotent methods are retried according to the RetryPolicy of the client. Each attempt waits for
+// the RateLimiter of the service that created the request, if any.
 func (c *Client) DoXML(req *http.Request, v interface{}) error {
-	resp, err := c.client.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return err
 	}
@@ -233,3 +261,11 @@ func checkResponseXML(r *http.Response) error {
 		HTTPStatusCode: r.StatusCode,
 	}
 }
+
+// send sends the request, unless the client is offline.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if err := cache.CheckOffline(req, c.Offline); err != nil {
+		return nil, err
+	}
+	return retry.Send(ratelimit.Client(c.client), req, c.RetryPolicy, retry.IsIdempotent(req.Method))
+}
@@ -0,0 +1,22 @@
+package geocodingsearchv7
+
+import "go.einride.tech/here/internal/cache"
+
+// DiskCache is a Cache storing responses as files in a directory, which persists between runs of a program.
+// Responses are stored content-addressed, named by their SHA-256 hash, so equal responses are stored once.
+// Each request is stored as a DiskCacheEntry with the metadata of its response.
+// It only supports keys returned by CacheKey, and is safe for concurrent use, also by several processes.
+//
+// Its MapVersion is recorded with stored responses, e.g. the map release of the API used, and responses stored
+// with another map version are not served. With KeepExpired, responses are served also after they have expired,
+// including responses which were never cacheable, e.g. responses of services without a CacheTTL.
+// Use with Client.Offline to develop against a recorded store.
+type DiskCache = cache.Disk
+
+// DiskCacheEntry is the metadata of a response stored in a DiskCache.
+type DiskCacheEntry = cache.DiskEntry
+
+// NewDiskCache returns a DiskCache storing responses in dir, which is created if it does not exist.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	return cache.NewDisk(dir)
+}
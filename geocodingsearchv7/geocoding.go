@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+
+	"go.einride.tech/here/internal/cache"
+	"go.einride.tech/here/internal/ratelimit"
 )
 
 // Geocoding allows forward geocoding of addresses and geo-positions.
@@ -37,12 +40,12 @@ func (s *GeocodingService) Geocoding(
 		values.Add("in", *req.In)
 	}
 
-	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodGet, values.Encode(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create get request: %v", err)
 	}
 	var resp GeocodingResponse
-	if err := s.Client.Do(r, &resp); err != nil {
+	if err := cache.Do(r, s.Client.Cache, s.CacheTTL, s.Client.Offline, &resp, s.Client.Do); err != nil {
 		return nil, err
 	}
 	return &resp, nil
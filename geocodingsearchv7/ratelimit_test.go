@@ -0,0 +1,36 @@
+package geocodingsearchv7_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"go.einride.tech/here/internal/httpmock"
+	"gotest.tools/v3/assert"
+)
+
+func TestClient_RateLimiter(t *testing.T) {
+	t.Parallel()
+	mock := &httpmock.Client{
+		StatusCodes: []int{429},
+		RetryAfter:  "0",
+		Body: func(*http.Request) string {
+			return `{"items":[]}`
+		},
+	}
+	client := geocodingsearchv7.NewClient(mock)
+	client.RetryPolicy = geocodingsearchv7.RetryPolicy{MaxAttempts: 2}
+	client.Geocoding.RateLimiter = geocodingsearchv7.NewRateLimiter(1000, 10)
+	client.ReverseGeocoding.RateLimiter = geocodingsearchv7.NewRateLimiter(1000, 10)
+	_, err := client.ReverseGeocoding.ReverseGeocoding(context.Background(), &geocodingsearchv7.ReverseGeocodingRequest{
+		GeoPosition: &geocodingsearchv7.GeoWaypoint{Lat: 59.33, Long: 18.06},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(mock.Bodies()))
+	// The rate is halved by the 429, and recovers by a twentieth with the successful retry.
+	stats := client.ReverseGeocoding.RateLimiter.Stats()
+	assert.Equal(t, 1, stats.Throttled)
+	assert.Equal(t, 550.0, stats.Rate)
+	assert.DeepEqual(t, geocodingsearchv7.RateLimiterStats{Rate: 1000}, client.Geocoding.RateLimiter.Stats())
+}
@@ -0,0 +1,8 @@
+package geocodingsearchv7
+
+import "go.einride.tech/here/internal/retry"
+
+// RetryPolicy configures how requests failing with a transient error are retried. An error is transient if the
+// request could not be sent, or if the response has status 429 Too Many Requests or a 5xx status.
+// The zero value disables retries.
+type RetryPolicy = retry.Policy
@@ -0,0 +1,83 @@
+package geocodingsearchv7_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"go.einride.tech/here/internal/httpmock"
+	"gotest.tools/v3/assert"
+)
+
+func TestClient_RetryPolicy(t *testing.T) {
+	t.Parallel()
+	policy := geocodingsearchv7.RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond}
+	reverseGeocoding := func(ctx context.Context, client *geocodingsearchv7.Client) error {
+		_, err := client.ReverseGeocoding.ReverseGeocoding(ctx, &geocodingsearchv7.ReverseGeocodingRequest{
+			GeoPosition: &geocodingsearchv7.GeoWaypoint{Lat: 59.33, Long: 18.06},
+		})
+		return err
+	}
+	batchUpload := func(ctx context.Context, client *geocodingsearchv7.Client) error {
+		_, err := client.BatchGeocoding.BatchGeocoderUpload(ctx, &geocodingsearchv7.BatchGeocoderUploadRequest{
+			Queries: []*geocodingsearchv7.QueryString{{RecID: "1", Query: "Regeringsgatan 65, Stockholm"}},
+		})
+		return err
+	}
+	for _, tt := range []struct {
+		name             string
+		policy           geocodingsearchv7.RetryPolicy
+		statusCodes      []int
+		call             func(context.Context, *geocodingsearchv7.Client) error
+		expectErr        bool
+		expectedAttempts int
+	}{
+		{
+			name:             "retries disabled by default",
+			statusCodes:      []int{503},
+			call:             reverseGeocoding,
+			expectErr:        true,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retry until success",
+			policy:           policy,
+			statusCodes:      []int{503, 429},
+			call:             reverseGeocoding,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "post is not retried",
+			policy:           policy,
+			statusCodes:      []int{503},
+			call:             batchUpload,
+			expectErr:        true,
+			expectedAttempts: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock := &httpmock.Client{
+				StatusCodes: tt.statusCodes,
+				Body: func(req *http.Request) string {
+					if req.Method == http.MethodPost {
+						return `<Response></Response>`
+					}
+					return `{"items":[]}`
+				},
+			}
+			client := geocodingsearchv7.NewClient(mock)
+			client.RetryPolicy = tt.policy
+			err := tt.call(context.Background(), client)
+			if tt.expectErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.Equal(t, tt.expectedAttempts, len(mock.Bodies()))
+		})
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+
+	"go.einride.tech/here/internal/cache"
+	"go.einride.tech/here/internal/ratelimit"
 )
 
 // ReverseGeocoding allows reverse geocode from geo-position to address.
@@ -31,12 +34,12 @@ func (s *ReverseGeocodingService) ReverseGeocoding(
 		values.Add("in", *req.In)
 	}
 
-	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodGet, values.Encode(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create get request: %v", err)
 	}
 	var resp ReverseGeocodingResponse
-	if err := s.Client.Do(r, &resp); err != nil {
+	if err := cache.Do(r, s.Client.Cache, s.CacheTTL, s.Client.Offline, &resp, s.Client.Do); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -0,0 +1,189 @@
+// Package cache caches raw API responses by a key derived from the request.
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cache stores raw API responses by a key derived from the request. Implementations must be safe for concurrent
+// use, and should treat their own failures as cache misses.
+type Cache interface {
+	// Get returns the response stored for the key, and false if there is none or it has expired.
+	Get(ctx context.Context, key string) ([]byte, bool)
+	// Set stores the response for the key, expiring after ttl. Responses with a ttl that is not positive must not
+	// be served by Get, but may be stored for offline use.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+}
+
+// ErrOffline is returned in offline mode for requests without a response in the cache.
+var ErrOffline = errors.New("offline: no cached response")
+
+// LRU is an in-memory Cache holding a maximum number of responses, evicting the least recently used response when
+// full. It is safe for concurrent use.
+type LRU struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRU returns an LRU holding up to maxEntries responses. If maxEntries is not positive, the number of responses
+// is not limited.
+func NewLRU(maxEntries int) *LRU {
+	return &LRU{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get implements Cache.
+func (c *LRU) Get(_ context.Context, key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if !time.Now().Before(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// Set implements Cache.
+func (c *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &lruEntry{key: key, value: value, expires: time.Now().Add(ttl)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	if c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of responses in the cache, including expired responses not yet evicted.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Key returns the key of the request in a Cache: a hex encoded SHA-256 hash of the canonicalized request.
+// The request is canonicalized by sorting the query parameters and the keys of a JSON body, and stripping the
+// apiKey parameter, so that equivalent requests have the same key.
+func Key(req *http.Request) (string, error) {
+	u := *req.URL
+	query := u.Query()
+	query.Del("apiKey")
+	u.RawQuery = query.Encode()
+	u.Fragment = ""
+	h := sha256.New()
+	_, _ = io.WriteString(h, req.Method+" "+u.String()+"\n")
+	if req.GetBody != nil && req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer body.Close()
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return "", err
+		}
+		_, _ = h.Write(canonicalJSON(b))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// canonicalJSON returns the JSON with object keys sorted and insignificant whitespace removed, or b as is if it
+// is not JSON.
+func canonicalJSON(b []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return b
+	}
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return b
+	}
+	return canonical
+}
+
+// Do decodes the JSON response to the request into v. The response is served from the cache if possible, and
+// otherwise sent with do, which writes the raw response to the io.Writer it is given, and stored in the cache for
+// ttl. Responses are only served from the cache if ttl is positive, or if offline, in which case do is never
+// called. If c is nil, the request is sent with do directly.
+func Do(
+	req *http.Request,
+	c Cache,
+	ttl time.Duration,
+	offline bool,
+	v interface{},
+	do func(*http.Request, interface{}) error,
+) error {
+	if c == nil {
+		return do(req, v)
+	}
+	key, err := Key(req)
+	if err != nil {
+		return err
+	}
+	if ttl > 0 || offline {
+		if b, ok := c.Get(req.Context(), key); ok {
+			return json.Unmarshal(b, v)
+		}
+	}
+	if offline {
+		return fmt.Errorf("%w for %s %s, key %s", ErrOffline, req.Method, req.URL.Path, key)
+	}
+	var buf bytes.Buffer
+	if err := do(req, &buf); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(buf.Bytes(), v); err != nil {
+		return err
+	}
+	c.Set(req.Context(), key, buf.Bytes(), ttl)
+	return nil
+}
+
+// CheckOffline returns an error wrapping ErrOffline if offline, for a request which is about to be sent.
+func CheckOffline(req *http.Request, offline bool) error {
+	if offline {
+		return fmt.Errorf("%w for %s %s", ErrOffline, req.Method, req.URL.Path)
+	}
+	return nil
+}
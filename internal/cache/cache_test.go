@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLRU(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cache := NewLRU(2)
+	cache.Set(ctx, "a", []byte("1"), time.Hour)
+	cache.Set(ctx, "b", []byte("2"), time.Hour)
+	_, ok := cache.Get(ctx, "a")
+	assert.Assert(t, ok)
+	// b is the least recently used, and evicted by c.
+	cache.Set(ctx, "c", []byte("3"), time.Hour)
+	_, ok = cache.Get(ctx, "b")
+	assert.Assert(t, !ok)
+	value, ok := cache.Get(ctx, "a")
+	assert.Assert(t, ok)
+	assert.Equal(t, "1", string(value))
+	assert.Equal(t, 2, cache.Len())
+	cache.Set(ctx, "d", []byte("4"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	_, ok = cache.Get(ctx, "d")
+	assert.Assert(t, !ok)
+	cache.Set(ctx, "e", []byte("5"), 0)
+	_, ok = cache.Get(ctx, "e")
+	assert.Assert(t, !ok)
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+	newRequest := func(method, rawURL, body string) *http.Request {
+		r, err := http.NewRequestWithContext(context.Background(), method, rawURL, bytes.NewReader([]byte(body)))
+		assert.NilError(t, err)
+		return r
+	}
+	key := func(r *http.Request) string {
+		k, err := Key(r)
+		assert.NilError(t, err)
+		return k
+	}
+	const routesURL = "https://router.hereapi.com/v8/routes"
+	base := key(newRequest(http.MethodPost, routesURL+"?a=1&b=2", `{"x":1,"y":[1,2]}`))
+	assert.Equal(t, 64, len(base))
+	assert.Equal(
+		t,
+		base,
+		key(newRequest(http.MethodPost, routesURL+"?b=2&apiKey=secret&a=1", `{"y": [1, 2], "x": 1}`)),
+	)
+	assert.Assert(t, base != key(newRequest(http.MethodPost, routesURL+"?a=1&b=3", `{"x":1,"y":[1,2]}`)))
+	assert.Assert(t, base != key(newRequest(http.MethodPost, routesURL+"?a=1&b=2", `{"x":1,"y":[2,1]}`)))
+	assert.Assert(t, base != key(newRequest(http.MethodGet, routesURL+"?a=1&b=2", "")))
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://router.hereapi.com/v8/routes?a=1", nil)
+	assert.NilError(t, err)
+	for _, tt := range []struct {
+		name          string
+		ttl           time.Duration
+		offline       bool
+		noCache       bool
+		cached        bool
+		sendErr       error
+		expectedErr   error
+		expectedSends int
+		expectedValue string
+	}{
+		{name: "sent and stored", ttl: time.Hour, expectedSends: 1, expectedValue: "sent"},
+		{name: "served from cache", ttl: time.Hour, cached: true, expectedValue: "cached"},
+		{name: "not served without ttl", cached: true, expectedSends: 1, expectedValue: "sent"},
+		{name: "offline", offline: true, cached: true, expectedValue: "cached"},
+		{name: "offline without response", offline: true, expectedErr: ErrOffline},
+		{name: "no cache", ttl: time.Hour, noCache: true, expectedSends: 1, expectedValue: "sent"},
+		{name: "send error", ttl: time.Hour, sendErr: errors.New("boom"), expectedSends: 1},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cache := NewLRU(0)
+			key, err := Key(req)
+			assert.NilError(t, err)
+			if tt.cached {
+				cache.Set(ctx, key, []byte(`"cached"`), time.Hour)
+			}
+			var sends int
+			// Send like the API clients, which write the raw response to an io.Writer and decode it otherwise.
+			send := func(_ *http.Request, v interface{}) error {
+				sends++
+				if tt.sendErr != nil {
+					return tt.sendErr
+				}
+				if w, ok := v.(io.Writer); ok {
+					_, err := io.WriteString(w, `"sent"`)
+					return err
+				}
+				return json.Unmarshal([]byte(`"sent"`), v)
+			}
+			var c Cache = cache
+			if tt.noCache {
+				c = nil
+			}
+			var value string
+			err = Do(req, c, tt.ttl, tt.offline, &value, send)
+			switch {
+			case tt.expectedErr != nil:
+				assert.ErrorIs(t, err, tt.expectedErr)
+			case tt.sendErr != nil:
+				assert.ErrorIs(t, err, tt.sendErr)
+				assert.Equal(t, 0, cache.Len())
+			default:
+				assert.NilError(t, err)
+			}
+			assert.Equal(t, tt.expectedSends, sends)
+			assert.Equal(t, tt.expectedValue, value)
+		})
+	}
+}
+
+func TestCheckOffline(t *testing.T) {
+	t.Parallel()
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodGet, "https://matrix.router.hereapi.com/v8/matrix/id/status", nil,
+	)
+	assert.NilError(t, err)
+	assert.NilError(t, CheckOffline(req, false))
+	err = CheckOffline(req, true)
+	assert.ErrorIs(t, err, ErrOffline)
+	assert.ErrorContains(t, err, "GET /v8/matrix/id/status")
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Disk is a Cache storing responses as files in a directory, which persists between runs of a program.
+// Responses are stored content-addressed, named by their SHA-256 hash, so equal responses are stored once.
+// Each request is stored as a DiskEntry with the metadata of its response.
+// It only supports keys returned by Key, and is safe for concurrent use, also by several processes.
+type Disk struct {
+	dir string
+	// MapVersion is recorded with stored responses, e.g. the map release of the API used.
+	// Responses stored with another map version are not served.
+	MapVersion string
+	// KeepExpired serves responses also after they have expired, including responses which were never cacheable,
+	// e.g. responses requested with a ttl that is not positive. Use with offline mode to develop against a recorded
+	// store.
+	KeepExpired bool
+}
+
+// DiskEntry is the metadata of a response stored in a Disk cache.
+type DiskEntry struct {
+	// RequestHash is the key of the request, see Key.
+	RequestHash string `json:"requestHash"`
+	// ContentHash is the hex encoded SHA-256 hash of the response, which names the file of the response.
+	ContentHash string `json:"contentHash"`
+	// Timestamp when the response was stored.
+	Timestamp time.Time `json:"timestamp"`
+	// Expires is the time after which the response is not served, unless KeepExpired is set.
+	Expires time.Time `json:"expires"`
+	// MapVersion of the cache when the response was stored.
+	MapVersion string `json:"mapVersion,omitempty"`
+}
+
+// NewDisk returns a Disk cache storing responses in dir, which is created if it does not exist.
+func NewDisk(dir string) (*Disk, error) {
+	for _, d := range []string{filepath.Join(dir, "objects"), filepath.Join(dir, "requests")} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &Disk{dir: dir}, nil
+}
+
+// Get implements Cache.
+func (c *Disk) Get(_ context.Context, key string) ([]byte, bool) {
+	entry, ok := c.Entry(key)
+	if !ok || entry.MapVersion != c.MapVersion {
+		return nil, false
+	}
+	if !c.KeepExpired && !time.Now().Before(entry.Expires) {
+		return nil, false
+	}
+	value, err := os.ReadFile(c.objectPath(entry.ContentHash))
+	if err != nil {
+		return nil, false
+	}
+	if sum := sha256.Sum256(value); hex.EncodeToString(sum[:]) != entry.ContentHash {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set implements Cache. Responses with a ttl that is not positive are stored already expired.
+func (c *Disk) Set(_ context.Context, key string, value []byte, ttl time.Duration) {
+	if !isKey(key) {
+		return
+	}
+	sum := sha256.Sum256(value)
+	now := time.Now()
+	entry := DiskEntry{
+		RequestHash: key,
+		ContentHash: hex.EncodeToString(sum[:]),
+		Timestamp:   now,
+		Expires:     now,
+		MapVersion:  c.MapVersion,
+	}
+	if ttl > 0 {
+		entry.Expires = now.Add(ttl)
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	// Write the response before the entry, so that an entry never refers to a missing response.
+	if err := writeFileAtomic(c.objectPath(entry.ContentHash), value); err != nil {
+		return
+	}
+	_ = writeFileAtomic(c.entryPath(key), b)
+}
+
+// Entry returns the metadata of the response stored for the key, and false if there is none.
+// Expired entries and entries of other map versions are also returned.
+func (c *Disk) Entry(key string) (*DiskEntry, bool) {
+	if !isKey(key) {
+		return nil, false
+	}
+	b, err := os.ReadFile(c.entryPath(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry DiskEntry
+	if err := json.Unmarshal(b, &entry); err != nil || !isKey(entry.ContentHash) {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (c *Disk) objectPath(contentHash string) string {
+	return filepath.Join(c.dir, "objects", contentHash[:2], contentHash)
+}
+
+func (c *Disk) entryPath(key string) string {
+	return filepath.Join(c.dir, "requests", key+".json")
+}
+
+// isKey returns true if s has the format of a key returned by Key, which makes it safe as a file name.
+func isKey(s string) bool {
+	if len(s) != 2*sha256.Size {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// writeFileAtomic writes the file through a temporary file, so that readers never see a partially written file.
+func writeFileAtomic(name string, data []byte) (err error) {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestDisk(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dir := t.TempDir()
+	cache, err := NewDisk(dir)
+	assert.NilError(t, err)
+	cache.MapVersion = "2021.1"
+	key1, key2, key3 := strings.Repeat("a", 64), strings.Repeat("b", 64), strings.Repeat("c", 64)
+	cache.Set(ctx, key1, []byte(`{"routes":[]}`), time.Hour)
+	cache.Set(ctx, key2, []byte(`{"routes":[]}`), time.Hour)
+	cache.Set(ctx, key3, []byte(`{"routes":[{}]}`), 0)
+	value, ok := cache.Get(ctx, key1)
+	assert.Assert(t, ok)
+	assert.Equal(t, `{"routes":[]}`, string(value))
+	entry, ok := cache.Entry(key1)
+	assert.Assert(t, ok)
+	assert.Equal(t, key1, entry.RequestHash)
+	assert.Equal(t, "2021.1", entry.MapVersion)
+	assert.Assert(t, entry.Expires.Sub(entry.Timestamp) == time.Hour)
+	// Equal responses are stored once.
+	objects, err := filepath.Glob(filepath.Join(dir, "objects", "*", "*"))
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(objects))
+	// Responses are overwritten by later writes.
+	cache.Set(ctx, key2, []byte(`{"routes":[{},{}]}`), time.Hour)
+	value, ok = cache.Get(ctx, key2)
+	assert.Assert(t, ok)
+	assert.Equal(t, `{"routes":[{},{}]}`, string(value))
+	// Responses stored expired are only served with KeepExpired.
+	_, ok = cache.Get(ctx, key3)
+	assert.Assert(t, !ok)
+	cache.KeepExpired = true
+	_, ok = cache.Get(ctx, key3)
+	assert.Assert(t, ok)
+	// Responses of other map versions are not served.
+	reopened, err := NewDisk(dir)
+	assert.NilError(t, err)
+	reopened.MapVersion = "2021.2"
+	_, ok = reopened.Get(ctx, key1)
+	assert.Assert(t, !ok)
+	reopened.MapVersion = "2021.1"
+	_, ok = reopened.Get(ctx, key1)
+	assert.Assert(t, ok)
+	// Keys not returned by Key are not supported.
+	cache.Set(ctx, "../key", []byte("{}"), time.Hour)
+	_, ok = cache.Get(ctx, "../key")
+	assert.Assert(t, !ok)
+	// No temporary files are left behind.
+	for _, pattern := range []string{filepath.Join(dir, "*", ".tmp-*"), filepath.Join(dir, "*", "*", ".tmp-*")} {
+		temporary, err := filepath.Glob(pattern)
+		assert.NilError(t, err)
+		assert.Equal(t, 0, len(temporary))
+	}
+}
+
+func TestDisk_Expiry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cache, err := NewDisk(t.TempDir())
+	assert.NilError(t, err)
+	key := strings.Repeat("a", 64)
+	cache.Set(ctx, key, []byte("{}"), 100*time.Millisecond)
+	_, ok := cache.Get(ctx, key)
+	assert.Assert(t, ok)
+	time.Sleep(150 * time.Millisecond)
+	_, ok = cache.Get(ctx, key)
+	assert.Assert(t, !ok)
+	// Expired entries are kept.
+	_, ok = cache.Entry(key)
+	assert.Assert(t, ok)
+	cache.KeepExpired = true
+	_, ok = cache.Get(ctx, key)
+	assert.Assert(t, ok)
+}
+
+func TestDisk_Corrupt(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	key := strings.Repeat("a", 64)
+	for _, tt := range []struct {
+		name    string
+		corrupt func(t *testing.T, dir string, entry *DiskEntry)
+	}{
+		{
+			name: "truncated response",
+			corrupt: func(t *testing.T, dir string, entry *DiskEntry) {
+				path := filepath.Join(dir, "objects", entry.ContentHash[:2], entry.ContentHash)
+				assert.NilError(t, os.WriteFile(path, nil, 0o600))
+			},
+		},
+		{
+			name: "missing response",
+			corrupt: func(t *testing.T, dir string, entry *DiskEntry) {
+				assert.NilError(t, os.Remove(filepath.Join(dir, "objects", entry.ContentHash[:2], entry.ContentHash)))
+			},
+		},
+		{
+			name: "invalid entry",
+			corrupt: func(t *testing.T, dir string, entry *DiskEntry) {
+				assert.NilError(t, os.WriteFile(filepath.Join(dir, "requests", key+".json"), []byte("{"), 0o600))
+			},
+		},
+		{
+			name: "entry with invalid content hash",
+			corrupt: func(t *testing.T, dir string, entry *DiskEntry) {
+				b := []byte(`{"requestHash":"` + key + `","contentHash":"../../x"}`)
+				assert.NilError(t, os.WriteFile(filepath.Join(dir, "requests", key+".json"), b, 0o600))
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			cache, err := NewDisk(dir)
+			assert.NilError(t, err)
+			cache.Set(ctx, key, []byte(`{"routes":[]}`), time.Hour)
+			entry, ok := cache.Entry(key)
+			assert.Assert(t, ok)
+			tt.corrupt(t, dir, entry)
+			_, ok = cache.Get(ctx, key)
+			assert.Assert(t, !ok)
+			// The corrupted response is replaced by the next write.
+			cache.Set(ctx, key, []byte(`{"routes":[]}`), time.Hour)
+			value, ok := cache.Get(ctx, key)
+			assert.Assert(t, ok)
+			assert.Equal(t, `{"routes":[]}`, string(value))
+		})
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	name := filepath.Join(dir, "sub", "file")
+	assert.NilError(t, writeFileAtomic(name, []byte("first")))
+	assert.NilError(t, writeFileAtomic(name, []byte("second")))
+	b, err := os.ReadFile(name)
+	assert.NilError(t, err)
+	assert.Equal(t, "second", string(b))
+	// A failed rename leaves neither the target nor the temporary file changed.
+	target := filepath.Join(dir, "sub", "directory")
+	assert.NilError(t, os.MkdirAll(filepath.Join(target, "child"), 0o755))
+	assert.Assert(t, writeFileAtomic(target, []byte("data")) != nil)
+	info, err := os.Stat(target)
+	assert.NilError(t, err)
+	assert.Assert(t, info.IsDir())
+	entries, err := os.ReadDir(filepath.Join(dir, "sub"))
+	assert.NilError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.DeepEqual(t, []string{"directory", "file"}, names)
+}
@@ -0,0 +1,67 @@
+// Package httpmock provides an HTTP client for tests of the API clients.
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Client responds to requests with StatusCodes in order, and with 200 OK once they are used up. It records the
+// body of each request, and is safe for concurrent use.
+type Client struct {
+	// StatusCodes of the responses, in order.
+	StatusCodes []int
+	// RetryAfter is the Retry-After header of each response, if set.
+	RetryAfter string
+	// Err is returned for each request instead of a response, if set.
+	Err error
+	// Body returns the body of the response to the request. The body is empty if not set.
+	Body func(req *http.Request) string
+
+	mu     sync.Mutex
+	bodies []string
+}
+
+// Do implements the HTTP client of the API clients.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var body string
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+	c.bodies = append(c.bodies, body)
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	statusCode := http.StatusOK
+	if len(c.StatusCodes) > 0 {
+		statusCode, c.StatusCodes = c.StatusCodes[0], c.StatusCodes[1:]
+	}
+	header := make(http.Header)
+	if c.RetryAfter != "" {
+		header.Set("Retry-After", c.RetryAfter)
+	}
+	var responseBody string
+	if c.Body != nil {
+		responseBody = c.Body(req)
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(responseBody)),
+	}, nil
+}
+
+// Bodies returns the body of each request, in the order the requests were sent.
+func (c *Client) Bodies() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.bodies...)
+}
@@ -0,0 +1,200 @@
+// Package ratelimit limits the rate of requests to a service with an adaptive token bucket.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// minFraction is the lowest fraction of the configured rate that a Limiter adapts down to.
+	minFraction = 1.0 / 16
+	// recoveryFraction of the configured rate is added back to the rate after each request that is not throttled.
+	recoveryFraction = 1.0 / 20
+)
+
+// Limiter limits the rate of requests to a service with a token bucket. It is safe for concurrent use.
+//
+// The limiter adapts to the API: when a request is throttled with 429 Too Many Requests, the rate is halved, and
+// it recovers gradually towards the configured rate with each request that is not throttled.
+type Limiter struct {
+	mu        sync.Mutex
+	limit     float64
+	rate      float64
+	burst     float64
+	tokens    float64
+	last      time.Time
+	waiting   int
+	totalWait time.Duration
+	throttled int
+}
+
+// Stats is a snapshot of the state of a Limiter.
+type Stats struct {
+	// Rate is the current rate in requests per second, which is below the configured rate after throttling.
+	Rate float64
+	// Wait is the time a request made now would wait before being sent.
+	Wait time.Duration
+	// Waiting is the number of requests currently waiting.
+	Waiting int
+	// TotalWait is the sum of the time all requests have waited.
+	TotalWait time.Duration
+	// Throttled is the number of requests throttled by the API.
+	Throttled int
+}
+
+// New returns a Limiter allowing qps requests per second on average, and bursts of up to burst requests.
+// If qps is not positive, requests are not limited.
+func New(qps float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		limit:  qps,
+		rate:   qps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent. It returns an error wrapping context.DeadlineExceeded without waiting
+// if ctx expires before then, and the error of ctx when it is done while waiting.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l.limit <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+	wait := l.wait()
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(now) < wait {
+		l.mu.Unlock()
+		return fmt.Errorf("rate limit: wait of %v exceeds context deadline: %w", wait, context.DeadlineExceeded)
+	}
+	l.tokens--
+	l.waiting++
+	l.totalWait += wait
+	l.mu.Unlock()
+	err := sleep(ctx, wait)
+	l.mu.Lock()
+	l.waiting--
+	if err != nil {
+		// Return the token, since no request is sent. The bucket may have been refilled while waiting.
+		l.tokens = math.Min(l.tokens+1, l.burst)
+	}
+	l.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("rate limit: %w", err)
+	}
+	return nil
+}
+
+// Stats returns a snapshot of the state of the limiter.
+func (l *Limiter) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limit > 0 {
+		l.refill(time.Now())
+	}
+	return Stats{
+		Rate:      l.rate,
+		Wait:      l.wait(),
+		Waiting:   l.waiting,
+		TotalWait: l.totalWait,
+		Throttled: l.throttled,
+	}
+}
+
+// observe adapts the rate to the response of a request sent after Wait. The response may be nil if the request
+// could not be sent.
+func (l *Limiter) observe(resp *http.Response) {
+	if l.limit <= 0 || resp == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	if resp.StatusCode == http.StatusTooManyRequests {
+		l.throttled++
+		l.rate = math.Max(l.rate/2, l.limit*minFraction)
+		l.tokens = math.Min(l.tokens, 0)
+		return
+	}
+	l.rate = math.Min(l.rate+l.limit*recoveryFraction, l.limit)
+}
+
+// Doer sends HTTP requests, e.g. an *http.Client.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the limiter of the service a request is sent to. The context is
+// returned as is if l is nil.
+func NewContext(ctx context.Context, l *Limiter) context.Context {
+	if l == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the limiter carried by ctx, if any.
+func FromContext(ctx context.Context) *Limiter {
+	l, _ := ctx.Value(contextKey{}).(*Limiter)
+	return l
+}
+
+// Client returns a Doer that sends each request with client, after waiting for the limiter carried by the context
+// of the request, if any. The limiter adapts to the responses.
+func Client(client Doer) Doer {
+	return limitedClient{client: client}
+}
+
+type limitedClient struct {
+	client Doer
+}
+
+func (c limitedClient) Do(req *http.Request) (*http.Response, error) {
+	l := FromContext(req.Context())
+	if l == nil {
+		return c.client.Do(req)
+	}
+	if err := l.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	l.observe(resp)
+	return resp, err
+}
+
+func (l *Limiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(l.tokens+elapsed.Seconds()*l.rate, l.burst)
+		l.last = now
+	}
+}
+
+// wait returns the time until a token is available, given that tokens are up to date.
+func (l *Limiter) wait() time.Duration {
+	if l.limit <= 0 || l.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/internal/httpmock"
+	"gotest.tools/v3/assert"
+)
+
+func TestLimiter_Wait(t *testing.T) {
+	t.Parallel()
+	t.Run("burst then rate", func(t *testing.T) {
+		t.Parallel()
+		limiter := New(50, 2)
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			assert.NilError(t, limiter.Wait(context.Background()))
+		}
+		// The burst is sent immediately, the remaining two requests wait 20ms each.
+		assert.Assert(t, time.Since(start) >= 30*time.Millisecond)
+	})
+	t.Run("wait exceeds deadline", func(t *testing.T) {
+		t.Parallel()
+		limiter := New(1, 1)
+		assert.NilError(t, limiter.Wait(context.Background()))
+		assert.Assert(t, limiter.Stats().Wait > 500*time.Millisecond)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := limiter.Wait(ctx)
+		assert.ErrorContains(t, err, "exceeds context deadline")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Assert(t, time.Since(start) < 10*time.Millisecond)
+	})
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+		limiter := New(1, 1)
+		assert.NilError(t, limiter.Wait(context.Background()))
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			for limiter.Stats().Waiting == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			// Refill the bucket as if the wait was over, before the wait is canceled.
+			limiter.mu.Lock()
+			limiter.tokens = limiter.burst
+			limiter.mu.Unlock()
+			cancel()
+		}()
+		assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+		assert.Equal(t, 0, limiter.Stats().Waiting)
+		// The wait is counted when it starts, also if it is canceled.
+		assert.Assert(t, limiter.Stats().TotalWait > 500*time.Millisecond)
+		// The returned token does not overflow the bucket.
+		limiter.mu.Lock()
+		defer limiter.mu.Unlock()
+		assert.Equal(t, limiter.burst, limiter.tokens)
+	})
+	t.Run("unlimited", func(t *testing.T) {
+		t.Parallel()
+		limiter := New(0, 0)
+		for i := 0; i < 100; i++ {
+			assert.NilError(t, limiter.Wait(context.Background()))
+		}
+		assert.Equal(t, time.Duration(0), limiter.Stats().TotalWait)
+	})
+}
+
+func TestLimiter_observe(t *testing.T) {
+	t.Parallel()
+	limiter := New(1000, 10)
+	limiter.observe(&http.Response{StatusCode: http.StatusTooManyRequests})
+	assert.Equal(t, 500.0, limiter.Stats().Rate)
+	for i := 0; i < 5; i++ {
+		limiter.observe(&http.Response{StatusCode: http.StatusTooManyRequests})
+	}
+	// The rate is not halved below a sixteenth of the limit.
+	assert.Equal(t, 62.5, limiter.Stats().Rate)
+	limiter.observe(&http.Response{StatusCode: http.StatusOK})
+	assert.Equal(t, 112.5, limiter.Stats().Rate)
+	limiter.observe(nil)
+	assert.Equal(t, 6, limiter.Stats().Throttled)
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+	limiter := New(1000, 10)
+	client := Client(&httpmock.Client{StatusCodes: []int{http.StatusTooManyRequests}})
+	for _, u := range []string{
+		"https://matrix.router.hereapi.com/v8/matrix",
+		// E.g. the result URL of an async matrix, on another host than the service.
+		"https://aws-eu-west-1.matrix.router.hereapi.com/v8/matrix/id",
+	} {
+		req, err := http.NewRequestWithContext(NewContext(context.Background(), limiter), http.MethodGet, u, nil)
+		assert.NilError(t, err)
+		resp, err := client.Do(req)
+		assert.NilError(t, err)
+		assert.NilError(t, resp.Body.Close())
+	}
+	// The rate is halved by the 429, and recovers by a twentieth with the successful request.
+	assert.Equal(t, 1, limiter.Stats().Throttled)
+	assert.Equal(t, 550.0, limiter.Stats().Rate)
+}
+
+func TestNewContext(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	assert.Equal(t, ctx, NewContext(ctx, nil))
+	assert.Assert(t, FromContext(ctx) == nil)
+	limiter := New(1, 1)
+	assert.Equal(t, limiter, FromContext(NewContext(ctx, limiter)))
+}
@@ -0,0 +1,203 @@
+// Package retry retries HTTP requests failing with a transient error, with exponential backoff.
+package retry
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultInitialInterval = 500 * time.Millisecond
+	defaultMaxInterval     = 30 * time.Second
+	defaultMultiplier      = 2
+	defaultJitter          = 0.2
+)
+
+// Policy configures how requests failing with a transient error are retried. An error is transient if the
+// request could not be sent, or if the response has status 429 Too Many Requests or a 5xx status.
+// The zero value disables retries, zero intervals are replaced by defaults.
+type Policy struct {
+	// MaxAttempts of a request, including the first one. Requests are not retried if less than 2.
+	MaxAttempts int
+	// InitialInterval to wait before the first retry. Defaults to 500ms.
+	InitialInterval time.Duration
+	// MaxInterval between two attempts. Defaults to 30s.
+	// A Retry-After header of the response takes precedence over the interval, but a request is not retried if
+	// the header asks to wait longer than MaxInterval.
+	MaxInterval time.Duration
+	// Multiplier applied to the interval after each attempt. Defaults to 2.
+	Multiplier float64
+	// Jitter is the fraction, between 0 and 1, by which each interval is randomly shortened, so that concurrent
+	// requests are not retried in lockstep. Defaults to 0.2, a negative value disables jitter.
+	Jitter float64
+}
+
+func (p Policy) withDefaults() Policy {
+	if p.InitialInterval <= 0 {
+		p.InitialInterval = defaultInitialInterval
+	}
+	if p.MaxInterval <= 0 {
+		p.MaxInterval = defaultMaxInterval
+	}
+	if p.MaxInterval < p.InitialInterval {
+		p.MaxInterval = p.InitialInterval
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = defaultMultiplier
+	}
+	switch {
+	case p.Jitter == 0:
+		p.Jitter = defaultJitter
+	case p.Jitter < 0:
+		p.Jitter = 0
+	case p.Jitter > 1:
+		p.Jitter = 1
+	}
+	return p
+}
+
+func (p Policy) next(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * p.Multiplier)
+	if next > p.MaxInterval {
+		return p.MaxInterval
+	}
+	return next
+}
+
+func (p Policy) jitter(interval time.Duration) time.Duration {
+	return time.Duration(float64(interval) * (1 - p.Jitter*rand.Float64())) //nolint: gosec
+}
+
+// Doer sends HTTP requests, e.g. an *http.Client.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Send sends the request with client, and retries it according to the policy if retryable is set.
+func Send(client Doer, req *http.Request, policy Policy, retryable bool) (*http.Response, error) {
+	if !retryable {
+		return client.Do(req)
+	}
+	return Do(client, req, policy)
+}
+
+// Do sends the request with client, and retries it according to the policy while it fails with a transient error.
+// A retry is given up if the request body cannot be rewound, if the response asks to retry after more than the
+// MaxInterval of the policy, or if the context expires before the next attempt.
+// The response of the last attempt is returned.
+func Do(client Doer, req *http.Request, policy Policy) (*http.Response, error) {
+	policy = policy.withDefaults()
+	interval := policy.InitialInterval
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(req)
+		if attempt >= policy.MaxAttempts || !isTransient(req.Context(), resp, err) {
+			return resp, err
+		}
+		wait := policy.jitter(interval)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > policy.MaxInterval {
+					return resp, err
+				}
+				wait = retryAfter
+			}
+		}
+		next, ok := rewind(req)
+		if !ok || !canWait(req.Context(), wait) {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		req = next
+		interval = policy.next(interval)
+	}
+}
+
+// IsIdempotent returns true if requests with the method can safely be sent more than once.
+func IsIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+func isTransient(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// rewind returns a copy of the request that can be sent again, with a fresh body.
+func rewind(req *http.Request) (*http.Request, bool) {
+	next := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return next, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	next.Body = body
+	return next, true
+}
+
+// canWait returns false if the context is done, or its deadline expires before the wait is over.
+func canWait(ctx context.Context, wait time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > wait
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
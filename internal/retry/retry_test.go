@@ -0,0 +1,185 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/internal/httpmock"
+	"gotest.tools/v3/assert"
+)
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+	policy := Policy{
+		MaxAttempts:     3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     2 * time.Millisecond,
+	}
+	for _, tt := range []struct {
+		name               string
+		policy             Policy
+		client             *httpmock.Client
+		withoutGetBody     bool
+		timeout            time.Duration
+		expectedStatusCode int
+		expectedErr        string
+		expectedAttempts   int
+	}{
+		{
+			name:               "disabled by default",
+			client:             &httpmock.Client{StatusCodes: []int{503}},
+			expectedStatusCode: 503,
+			expectedAttempts:   1,
+		},
+		{
+			name:               "until success",
+			policy:             policy,
+			client:             &httpmock.Client{StatusCodes: []int{503, 429}},
+			expectedStatusCode: 200,
+			expectedAttempts:   3,
+		},
+		{
+			name:               "max attempts",
+			policy:             policy,
+			client:             &httpmock.Client{StatusCodes: []int{502, 503, 504}},
+			expectedStatusCode: 504,
+			expectedAttempts:   3,
+		},
+		{
+			name:               "client errors are not retried",
+			policy:             policy,
+			client:             &httpmock.Client{StatusCodes: []int{400}},
+			expectedStatusCode: 400,
+			expectedAttempts:   1,
+		},
+		{
+			name:             "transport errors",
+			policy:           policy,
+			client:           &httpmock.Client{Err: errors.New("connection reset")},
+			expectedErr:      "connection reset",
+			expectedAttempts: 3,
+		},
+		{
+			name:               "retry after",
+			policy:             policy,
+			client:             &httpmock.Client{StatusCodes: []int{429}, RetryAfter: "0"},
+			expectedStatusCode: 200,
+			expectedAttempts:   2,
+		},
+		{
+			name:               "retry after exceeds max interval",
+			policy:             policy,
+			client:             &httpmock.Client{StatusCodes: []int{429}, RetryAfter: "86400"},
+			expectedStatusCode: 429,
+			expectedAttempts:   1,
+		},
+		{
+			name:               "retry after exceeds deadline",
+			policy:             Policy{MaxAttempts: 3, MaxInterval: time.Hour},
+			client:             &httpmock.Client{StatusCodes: []int{429}, RetryAfter: "60"},
+			timeout:            time.Second,
+			expectedStatusCode: 429,
+			expectedAttempts:   1,
+		},
+		{
+			name:               "body cannot be rewound",
+			policy:             policy,
+			client:             &httpmock.Client{StatusCodes: []int{503}},
+			withoutGetBody:     true,
+			expectedStatusCode: 503,
+			expectedAttempts:   1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://example.com", strings.NewReader("body"))
+			assert.NilError(t, err)
+			if tt.withoutGetBody {
+				req.GetBody = nil
+			}
+			start := time.Now()
+			resp, err := Do(tt.client, req, tt.policy)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+			}
+			assert.Assert(t, time.Since(start) < time.Second)
+			bodies := tt.client.Bodies()
+			assert.Equal(t, tt.expectedAttempts, len(bodies))
+			for _, body := range bodies {
+				// The body is sent again on each attempt.
+				assert.Equal(t, "body", body)
+			}
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+	policy := Policy{MaxAttempts: 3, InitialInterval: time.Millisecond}
+	for _, tt := range []struct {
+		name             string
+		retryable        bool
+		expectedAttempts int
+	}{
+		{name: "retryable", retryable: true, expectedAttempts: 2},
+		{name: "not retryable", expectedAttempts: 1},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := &httpmock.Client{StatusCodes: []int{503}}
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com", nil)
+			assert.NilError(t, err)
+			_, err = Send(client, req, policy, tt.retryable)
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expectedAttempts, len(client.Bodies()))
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2021, 11, 1, 8, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{value: "", ok: false},
+		{value: "120", expected: 2 * time.Minute, ok: true},
+		{value: "-1", ok: false},
+		{value: "Mon, 01 Nov 2021 08:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{value: "Mon, 01 Nov 2021 07:59:00 GMT", expected: 0, ok: true},
+		{value: "soon", ok: false},
+	} {
+		tt := tt
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			got, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	t.Parallel()
+	assert.Assert(t, IsIdempotent(http.MethodGet))
+	assert.Assert(t, IsIdempotent(http.MethodPut))
+	assert.Assert(t, !IsIdempotent(http.MethodPost))
+	assert.Assert(t, !IsIdempotent(http.MethodPatch))
+}
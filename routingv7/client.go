@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+
+	"go.einride.tech/here/internal/retry"
 )
 
 const (
@@ -26,6 +28,10 @@ type Client struct {
 
 	UserAgent string
 
+	// RetryPolicy configures how requests failing with a transient error are retried. Requests are not retried by
+	// default.
+	RetryPolicy RetryPolicy
+
 	// Reuse a single struct instead of allocating one for each service on the heap.
 	common service
 
@@ -110,8 +116,9 @@ func (c *Client) NewRequest(
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
+// Requests with idempotent methods are retried according to the RetryPolicy of the client.
 func (c *Client) Do(req *http.Request, v interface{}) error {
-	resp, err := c.client.Do(req)
+	resp, err := retry.Send(c.client, req, c.RetryPolicy, retry.IsIdempotent(req.Method))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,353 @@
+package routingv8
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Avoid defines features, areas, segments and zones that routes should avoid.
+// The same Avoid can be used in RoutesRequest and CalculateMatrixBody, so that matrix costs match the final route.
+type Avoid struct {
+	// Features to avoid, e.g. ferries or toll roads.
+	Features []AreaFeature
+	// Areas to avoid.
+	Areas []AvoidArea
+	// Segments to avoid, as segment references, e.g. "here:cm:segment:76771992#+".
+	// The direction suffix is optional.
+	Segments []string
+	// ZoneCategories to avoid, e.g. environmental zones.
+	ZoneCategories []ZoneCategory
+}
+
+// Exclude defines regions that routes must not pass through.
+type Exclude struct {
+	// Countries to exclude, as ISO 3166-1 alpha-3 country codes, e.g. "DEU".
+	Countries []string
+}
+
+type AvoidAreaType int
+
+const (
+	AvoidAreaTypeUnspecified AvoidAreaType = iota
+	AvoidAreaTypeBoundingBox
+	AvoidAreaTypePolygon
+	AvoidAreaTypeCorridor
+)
+
+func (a *AvoidAreaType) String() string {
+	switch *a {
+	case AvoidAreaTypeUnspecified:
+		return unspecified
+	case AvoidAreaTypeBoundingBox:
+		return "boundingBox"
+	case AvoidAreaTypePolygon:
+		return "polygon"
+	case AvoidAreaTypeCorridor:
+		return "corridor"
+	default:
+		return invalid
+	}
+}
+
+// AvoidArea is an area to avoid. Which fields are used depends on the Type.
+type AvoidArea struct {
+	Type AvoidAreaType
+	// BoundingBox in degrees.
+	BoundingBoxNorth float64
+	BoundingBoxEast  float64
+	BoundingBoxSouth float64
+	BoundingBoxWest  float64
+	// Polygon defined by between 3 and 20 points. The polygon is closed automatically.
+	PolygonOuter []GeoWaypoint
+	// Corridor along a polyline of at least 2 points, with a radius in meters.
+	CorridorPoints []GeoWaypoint
+	CorridorRadius int
+}
+
+func (a *AvoidArea) validate() error {
+	switch a.Type {
+	case AvoidAreaTypeBoundingBox:
+		if a.BoundingBoxNorth < a.BoundingBoxSouth {
+			return fmt.Errorf("invalid bounding box, north must not be less than south")
+		}
+		for _, lat := range []float64{a.BoundingBoxNorth, a.BoundingBoxSouth} {
+			if lat < -90 || lat > 90 {
+				return fmt.Errorf("invalid bounding box, latitude %v out of range", lat)
+			}
+		}
+		for _, lng := range []float64{a.BoundingBoxEast, a.BoundingBoxWest} {
+			if lng < -180 || lng > 180 {
+				return fmt.Errorf("invalid bounding box, longitude %v out of range", lng)
+			}
+		}
+	case AvoidAreaTypePolygon:
+		if len(a.PolygonOuter) < 3 || len(a.PolygonOuter) > 20 {
+			return fmt.Errorf("invalid polygon, must have between 3 and 20 points, got %d", len(a.PolygonOuter))
+		}
+	case AvoidAreaTypeCorridor:
+		if len(a.CorridorPoints) < 2 {
+			return fmt.Errorf("invalid corridor, must have at least 2 points, got %d", len(a.CorridorPoints))
+		}
+		if a.CorridorRadius <= 0 {
+			return fmt.Errorf("invalid corridor, radius must be positive")
+		}
+	default:
+		return fmt.Errorf("invalid avoid area type")
+	}
+	return nil
+}
+
+// queryString returns the area in the format of the avoid[areas] query parameter.
+func (a *AvoidArea) queryString() (string, error) {
+	switch a.Type {
+	case AvoidAreaTypeBoundingBox:
+		return fmt.Sprintf(
+			"bbox:%v,%v,%v,%v",
+			a.BoundingBoxWest,
+			a.BoundingBoxSouth,
+			a.BoundingBoxEast,
+			a.BoundingBoxNorth,
+		), nil
+	case AvoidAreaTypePolygon:
+		return "polygon:" + formatPoints(a.PolygonOuter), nil
+	case AvoidAreaTypeCorridor:
+		polyline, err := a.corridorPolyline()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("corridor:%s;r=%d", polyline, a.CorridorRadius), nil
+	default:
+		return invalid, nil
+	}
+}
+
+// corridorPolyline returns the points of a corridor as a Flexible Polyline, which is the format expected by the
+// API in both the query and the body.
+func (a *AvoidArea) corridorPolyline() (Polyline, error) {
+	return EncodePolyline(PolylineHeader{Precision: 5}, a.CorridorPoints)
+}
+
+func (a AvoidArea) MarshalJSON() ([]byte, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	switch a.Type {
+	case AvoidAreaTypeBoundingBox:
+		return json.Marshal(struct {
+			Type  string  `json:"type"`
+			North float64 `json:"north"`
+			South float64 `json:"south"`
+			West  float64 `json:"west"`
+			East  float64 `json:"east"`
+		}{
+			Type:  a.Type.String(),
+			North: a.BoundingBoxNorth,
+			South: a.BoundingBoxSouth,
+			West:  a.BoundingBoxWest,
+			East:  a.BoundingBoxEast,
+		})
+	case AvoidAreaTypePolygon:
+		return json.Marshal(struct {
+			Type  string        `json:"type"`
+			Outer []GeoWaypoint `json:"outer"`
+		}{
+			Type:  a.Type.String(),
+			Outer: a.PolygonOuter,
+		})
+	default:
+		polyline, err := a.corridorPolyline()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(struct {
+			Type     string   `json:"type"`
+			Polyline Polyline `json:"polyline"`
+			Radius   int      `json:"radius"`
+		}{
+			Type:     a.Type.String(),
+			Polyline: polyline,
+			Radius:   a.CorridorRadius,
+		})
+	}
+}
+
+type ZoneCategory int
+
+const (
+	ZoneCategoryUnspecified ZoneCategory = iota
+	ZoneCategoryVignette
+	ZoneCategoryCongestionPricing
+	ZoneCategoryEnvironmental
+)
+
+func (z *ZoneCategory) String() string {
+	switch *z {
+	case ZoneCategoryUnspecified:
+		return unspecified
+	case ZoneCategoryVignette:
+		return "vignette"
+	case ZoneCategoryCongestionPricing:
+		return "congestionPricing"
+	case ZoneCategoryEnvironmental:
+		return "environmental"
+	default:
+		return invalid
+	}
+}
+
+func (a *Avoid) zoneCategories() ([]string, error) {
+	categories := make([]string, 0, len(a.ZoneCategories))
+	for _, category := range a.ZoneCategories {
+		c := category.String()
+		if c == invalid {
+			return nil, fmt.Errorf("invalid zone category")
+		}
+		if c != unspecified {
+			categories = append(categories, c)
+		}
+	}
+	return categories, nil
+}
+
+func (a *Avoid) validate() error {
+	for i := range a.Areas {
+		if err := a.Areas[i].validate(); err != nil {
+			return err
+		}
+	}
+	for _, segment := range a.Segments {
+		if segment == "" || strings.Contains(segment, ",") {
+			return fmt.Errorf("invalid avoid segment %q", segment)
+		}
+	}
+	return nil
+}
+
+// addQueryValues adds the avoid[...] parameters, except avoid[features] which is merged with
+// RoutesRequest.AvoidAreas by the caller.
+func (a *Avoid) addQueryValues(values url.Values) error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+	if len(a.Areas) > 0 {
+		areas := make([]string, 0, len(a.Areas))
+		for i := range a.Areas {
+			area, err := a.Areas[i].queryString()
+			if err != nil {
+				return err
+			}
+			areas = append(areas, area)
+		}
+		values.Add("avoid[areas]", strings.Join(areas, "|"))
+	}
+	if len(a.Segments) > 0 {
+		values.Add("avoid[segments]", strings.Join(a.Segments, ","))
+	}
+	categories, err := a.zoneCategories()
+	if err != nil {
+		return err
+	}
+	if len(categories) > 0 {
+		values.Add("avoid[zoneCategories]", strings.Join(categories, ","))
+	}
+	return nil
+}
+
+func (a Avoid) MarshalJSON() ([]byte, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	features, err := featureStrings(a.Features)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := a.zoneCategories()
+	if err != nil {
+		return nil, err
+	}
+	type zoneCategories struct {
+		Categories []string `json:"categories"`
+	}
+	body := struct {
+		Features       []string        `json:"features,omitempty"`
+		Areas          []AvoidArea     `json:"areas,omitempty"`
+		Segments       []string        `json:"segments,omitempty"`
+		ZoneCategories *zoneCategories `json:"zoneCategories,omitempty"`
+	}{
+		Features: features,
+		Areas:    a.Areas,
+		Segments: a.Segments,
+	}
+	if len(categories) > 0 {
+		body.ZoneCategories = &zoneCategories{Categories: categories}
+	}
+	return json.Marshal(body)
+}
+
+func (e *Exclude) validate() error {
+	for _, country := range e.Countries {
+		if !isAlpha3Code(country) {
+			return fmt.Errorf("invalid exclude country %q, must be an ISO 3166-1 alpha-3 code", country)
+		}
+	}
+	return nil
+}
+
+func (e *Exclude) addQueryValues(values url.Values) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+	if len(e.Countries) > 0 {
+		values.Add("exclude[countries]", strings.Join(e.Countries, ","))
+	}
+	return nil
+}
+
+func (e Exclude) MarshalJSON() ([]byte, error) {
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Countries []string `json:"countries,omitempty"`
+	}{
+		Countries: e.Countries,
+	})
+}
+
+func featureStrings(features []AreaFeature) ([]string, error) {
+	result := make([]string, 0, len(features))
+	for _, feature := range features {
+		f := feature.String()
+		if f == invalid {
+			return nil, fmt.Errorf("invalid avoid area")
+		}
+		if f != unspecified {
+			result = append(result, f)
+		}
+	}
+	return result, nil
+}
+
+func formatPoints(points []GeoWaypoint) string {
+	formatted := make([]string, 0, len(points))
+	for _, point := range points {
+		formatted = append(formatted, fmt.Sprintf("%v,%v", point.Lat, point.Long))
+	}
+	return strings.Join(formatted, ";")
+}
+
+// isAlpha3Code returns true if s is a code of three upper case letters, e.g. an ISO 3166-1 alpha-3 or ISO 4217
+// code.
+func isAlpha3Code(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
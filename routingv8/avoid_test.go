@@ -0,0 +1,64 @@
+package routingv8_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestAvoid_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	body := routingv8.CalculateMatrixBody{
+		Avoid: &routingv8.Avoid{
+			Features: []routingv8.AreaFeature{routingv8.AreaFeatureFerry},
+			Areas: []routingv8.AvoidArea{
+				{
+					Type:             routingv8.AvoidAreaTypeBoundingBox,
+					BoundingBoxNorth: 57.8,
+					BoundingBoxEast:  12.1,
+					BoundingBoxSouth: 57.6,
+					BoundingBoxWest:  11.8,
+				},
+				{
+					Type: routingv8.AvoidAreaTypePolygon,
+					PolygonOuter: []routingv8.GeoWaypoint{
+						{Lat: 58.1, Long: 14.1},
+						{Lat: 58.2, Long: 14.2},
+						{Lat: 58.1, Long: 14.3},
+					},
+				},
+				{
+					Type: routingv8.AvoidAreaTypeCorridor,
+					CorridorPoints: []routingv8.GeoWaypoint{
+						{Lat: 50.1022829, Long: 8.6982122},
+						{Lat: 50.1020076, Long: 8.6956695},
+					},
+					CorridorRadius: 500,
+				},
+			},
+			Segments:       []string{"here:cm:segment:76771992#+"},
+			ZoneCategories: []routingv8.ZoneCategory{routingv8.ZoneCategoryEnvironmental},
+		},
+		Exclude: &routingv8.Exclude{Countries: []string{"NOR"}},
+	}
+	b, err := json.Marshal(body.Avoid)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		`{"features":["ferry"],"areas":[`+
+			`{"type":"boundingBox","north":57.8,"south":57.6,"west":11.8,"east":12.1},`+
+			`{"type":"polygon","outer":[{"lat":58.1,"lng":14.1},{"lat":58.2,"lng":14.2},{"lat":58.1,"lng":14.3}]},`+
+			`{"type":"corridor","polyline":"BFoz5xJ67i1B1B7P","radius":500}],`+
+			`"segments":["here:cm:segment:76771992#+"],"zoneCategories":{"categories":["environmental"]}}`,
+		string(b),
+	)
+	b, err = json.Marshal(body.Exclude)
+	assert.NilError(t, err)
+	assert.Equal(t, `{"countries":["NOR"]}`, string(b))
+
+	body.Avoid.Areas = []routingv8.AvoidArea{{Type: routingv8.AvoidAreaTypeCorridor}}
+	_, err = json.Marshal(&body)
+	assert.ErrorContains(t, err, "invalid corridor")
+}
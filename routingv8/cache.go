@@ -0,0 +1,63 @@
+package routingv8
+
+import (
+	"net/http"
+	"time"
+
+	"go.einride.tech/here/internal/cache"
+)
+
+// Cache stores raw API responses by a key derived from the request. Implementations must be safe for concurrent
+// use, and should treat their own failures as cache misses.
+//
+// Get returns the response stored for a key, and false if there is none or it has expired. Set stores the response
+// for a key, expiring after ttl. Responses with a ttl that is not positive must not be served by Get, but may be
+// stored for offline use, see Client.Offline.
+type Cache = cache.Cache
+
+// ErrOffline is returned in offline mode for requests without a response in the cache, see Client.Offline.
+var ErrOffline = cache.ErrOffline
+
+// LRUCache is an in-memory Cache holding a maximum number of responses, evicting the least recently used response
+// when full. It is safe for concurrent use.
+type LRUCache = cache.LRU
+
+// NewLRUCache returns an LRUCache holding up to maxEntries responses. If maxEntries is not positive, the number
+// of responses is not limited.
+func NewLRUCache(maxEntries int) *LRUCache {
+	return cache.NewLRU(maxEntries)
+}
+
+// CacheKey returns the key of the request in a Cache: a hex encoded SHA-256 hash of the canonicalized request.
+// The request is canonicalized by sorting the query parameters and the keys of a JSON body, and stripping the
+// apiKey parameter, so that equivalent requests have the same key.
+func CacheKey(req *http.Request) (string, error) {
+	return cache.Key(req)
+}
+
+// cacheTTL returns how long the response to the request may be cached, given the TTL of the service.
+// Routes departing now depend on the current traffic, and are never cached. Routes departing or arriving at a
+// given time are cached until that time at the latest, and routes with DepartureTimeAny for the full TTL.
+func (r *RoutesRequest) cacheTTL(ttl time.Duration, now time.Time) time.Duration {
+	var t time.Time
+	switch {
+	case r.DepartureTime == DepartureTimeAny:
+		return ttl
+	case !r.DepartAt.IsZero():
+		t = r.DepartAt
+	case !r.ArriveAt.IsZero():
+		t = r.ArriveAt
+	case r.DepartureTime != "":
+		parsed, err := time.Parse(time.RFC3339, r.DepartureTime)
+		if err != nil {
+			return 0
+		}
+		t = parsed
+	default:
+		return 0
+	}
+	if until := t.Sub(now); until < ttl {
+		return until
+	}
+	return ttl
+}
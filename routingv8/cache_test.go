@@ -0,0 +1,67 @@
+package routingv8_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/internal/httpmock"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestRoutingService_Routes_Cache(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name             string
+		departureTime    string
+		departAt         time.Time
+		expectedRequests int
+	}{
+		{
+			name:             "departure time any",
+			departureTime:    routingv8.DepartureTimeAny,
+			expectedRequests: 1,
+		},
+		{
+			name:             "departing now",
+			expectedRequests: 2,
+		},
+		{
+			name:             "departing in the future",
+			departAt:         time.Now().Add(time.Hour),
+			expectedRequests: 1,
+		},
+		{
+			name:             "departed in the past",
+			departAt:         time.Now().Add(-time.Hour),
+			expectedRequests: 2,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock := &httpmock.Client{
+				Body: func(*http.Request) string {
+					return `{"routes":[]}`
+				},
+			}
+			client := routingv8.NewClient(mock)
+			client.Cache = routingv8.NewLRUCache(10)
+			client.Routing.CacheTTL = time.Hour
+			for i := 0; i < 2; i++ {
+				resp, err := client.Routing.Routes(context.Background(), &routingv8.RoutesRequest{
+					Origin:        routingv8.GeoWaypoint{Lat: 1, Long: 2},
+					Destination:   routingv8.GeoWaypoint{Lat: 3, Long: 4},
+					TransportMode: routingv8.TransportModeCar,
+					DepartureTime: tt.departureTime,
+					DepartAt:      tt.departAt,
+				})
+				assert.NilError(t, err)
+				assert.Assert(t, resp != nil)
+			}
+			assert.Equal(t, tt.expectedRequests, len(mock.Bodies()))
+		})
+	}
+}
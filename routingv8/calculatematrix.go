@@ -3,11 +3,56 @@ package routingv8
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
+
+	"go.einride.tech/here/internal/ratelimit"
+)
+
+const (
+	defaultMatrixPollingInitialInterval = 500 * time.Millisecond
+	defaultMatrixPollingMaxInterval     = 10 * time.Second
+	defaultMatrixPollingMultiplier      = 1.5
 )
 
+// MatrixPollingBackoff configures the interval between status requests when waiting for an async matrix.
+// Zero values are replaced by defaults.
+type MatrixPollingBackoff struct {
+	// InitialInterval to wait before polling the status again. Defaults to 500ms.
+	InitialInterval time.Duration
+	// MaxInterval between two status requests. Defaults to 10s.
+	MaxInterval time.Duration
+	// Multiplier applied to the interval after each status request. Defaults to 1.5.
+	Multiplier float64
+}
+
+func (b MatrixPollingBackoff) withDefaults() MatrixPollingBackoff {
+	if b.InitialInterval <= 0 {
+		b.InitialInterval = defaultMatrixPollingInitialInterval
+	}
+	if b.MaxInterval <= 0 {
+		b.MaxInterval = defaultMatrixPollingMaxInterval
+	}
+	if b.MaxInterval < b.InitialInterval {
+		b.MaxInterval = b.InitialInterval
+	}
+	if b.Multiplier < 1 {
+		b.Multiplier = defaultMatrixPollingMultiplier
+	}
+	return b
+}
+
+func (b MatrixPollingBackoff) next(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * b.Multiplier)
+	if next > b.MaxInterval {
+		return b.MaxInterval
+	}
+	return next
+}
+
 func (c *CalculateMatrixRequest) QueryString() string {
 	values := make(url.Values)
 	values.Add("async", c.Async.String())
@@ -18,6 +63,9 @@ func (c *CalculateMatrixRequest) QueryString() string {
 // The required parameters for this resource are a region definition and a set of start and destination waypoints.
 // See https://developer.here.com/documentation/matrix-routing-api/8.6.0/dev_guide/topics/get-started/send-request.html
 // for details about other parameters.
+//
+// If the request is async, the response only contains the MatrixID, Status and StatusURL of the calculation.
+// Use WaitForMatrix to wait for the calculation to finish and download the result.
 func (s *MatrixService) CalculateMatrix(
 	ctx context.Context,
 	req *CalculateMatrixRequest,
@@ -35,11 +83,168 @@ func (s *MatrixService) CalculateMatrix(
 	if err != nil {
 		return nil, err
 	}
-	r, err := s.Client.NewRequest(ctx, u, http.MethodPost, req.QueryString(), bytes)
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodPost, req.QueryString(), bytes)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create post request: %v", err)
 	}
 	var resp CalculateMatrixResponse
+	if err := s.Client.do(r, &resp, s.Client.RetryCalculateMatrix); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetMatrixStatus returns the status of an async matrix calculation.
+// See https://developer.here.com/documentation/matrix-routing-api/8.6.0/api-reference-swagger.html
+// for details about the async matrix lifecycle.
+func (s *MatrixService) GetMatrixStatus(
+	ctx context.Context,
+	matrixID string,
+) (_ *MatrixStatusResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("get matrix status %s: %v", matrixID, err)
+		}
+	}()
+	resp, err := s.getMatrixStatus(ctx, matrixID)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.MatrixStatusResponse, nil
+}
+
+// DownloadMatrix returns the result of a completed async matrix calculation.
+func (s *MatrixService) DownloadMatrix(
+	ctx context.Context,
+	matrixID string,
+) (_ *CalculateMatrixResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("download matrix %s: %v", matrixID, err)
+		}
+	}()
+	if matrixID == "" {
+		return nil, fmt.Errorf("InvalidArgument, matrixID can not be empty")
+	}
+	u, err := s.URL.Parse("matrix/" + url.PathEscape(matrixID))
+	if err != nil {
+		return nil, err
+	}
+	return s.downloadMatrix(ctx, u)
+}
+
+// WaitForMatrix polls the status of an async matrix calculation until it has finished, and returns the result.
+// The interval between status requests is configured by Client.MatrixPolling.
+func (s *MatrixService) WaitForMatrix(
+	ctx context.Context,
+	matrixID string,
+) (_ *CalculateMatrixResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("wait for matrix %s: %v", matrixID, err)
+		}
+	}()
+	backoff := s.Client.MatrixPolling.withDefaults()
+	interval := backoff.InitialInterval
+	for {
+		status, err := s.getMatrixStatus(ctx, matrixID)
+		if err != nil {
+			return nil, err
+		}
+		switch status.Status {
+		case MatrixStatusCompleted:
+			if status.Matrix != nil {
+				// The HTTP client followed the redirect to the result.
+				return &CalculateMatrixResponse{
+					MatrixID:         status.MatrixID,
+					Matrix:           *status.Matrix,
+					RegionDefinition: status.RegionDefinition,
+				}, nil
+			}
+			if status.ResultURL == "" {
+				return s.DownloadMatrix(ctx, matrixID)
+			}
+			u, err := url.Parse(status.ResultURL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid result url: %v", err)
+			}
+			return s.downloadMatrix(ctx, u)
+		case MatrixStatusAccepted, MatrixStatusInProgress:
+		default:
+			// Any other status, e.g. failed or timedOut, is terminal.
+			return nil, status.err()
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		interval = backoff.next(interval)
+	}
+}
+
+// matrixStatusOrResult is either a status response, or the result of the calculation if the HTTP client
+// followed the redirect from the status of a completed calculation.
+type matrixStatusOrResult struct {
+	MatrixStatusResponse
+	Matrix           *MatrixResponse  `json:"matrix"`
+	RegionDefinition RegionDefinition `json:"regionDefinition"`
+}
+
+// err returns the error of a calculation with a terminal status other than completed.
+func (r *matrixStatusOrResult) err() error {
+	if r.Error == nil {
+		return fmt.Errorf("calculation %q", r.Status)
+	}
+	body, err := json.Marshal(r.Error)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("calculation %q: %w", r.Status, &ResponseError{
+		Response:       r.Error,
+		HTTPBody:       string(body),
+		HTTPStatusCode: r.Error.Status,
+	})
+}
+
+func (s *MatrixService) getMatrixStatus(ctx context.Context, matrixID string) (*matrixStatusOrResult, error) {
+	if matrixID == "" {
+		return nil, fmt.Errorf("InvalidArgument, matrixID can not be empty")
+	}
+	u, err := s.URL.Parse("matrix/" + url.PathEscape(matrixID) + "/status")
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodGet, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create get request: %v", err)
+	}
+	var resp matrixStatusOrResult
+	if err := s.Client.Do(r, &resp); err != nil {
+		// A completed calculation responds with a redirect to the result, which is returned as an error
+		// when the HTTP client does not follow redirects.
+		var responseError *ResponseError
+		if !errors.As(err, &responseError) || responseError.HTTPStatusCode != http.StatusSeeOther {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(responseError.HTTPBody), &resp); err != nil {
+			return nil, err
+		}
+	}
+	if resp.Matrix != nil {
+		resp.Status = MatrixStatusCompleted
+	}
+	return &resp, nil
+}
+
+func (s *MatrixService) downloadMatrix(ctx context.Context, u *url.URL) (*CalculateMatrixResponse, error) {
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodGet, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create get request: %v", err)
+	}
+	var resp CalculateMatrixResponse
 	if err := s.Client.Do(r, &resp); err != nil {
 		return nil, err
 	}
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"go.einride.tech/here/routingv8"
 	"gotest.tools/v3/assert"
@@ -84,3 +88,216 @@ func TestMatrixService_CalculateMatrix(t *testing.T) {
 	assert.NilError(t, err)
 	assert.DeepEqual(t, &exp, got)
 }
+
+type mockResponse struct {
+	status int
+	body   string
+}
+
+type AsyncMatrixMock struct {
+	mu        sync.Mutex
+	responses map[string][]mockResponse
+	requests  []string
+}
+
+func (c *AsyncMatrixMock) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := req.Method + " " + req.URL.Path
+	c.requests = append(c.requests, key)
+	responses := c.responses[key]
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("unexpected request %s", key)
+	}
+	resp := responses[0]
+	if len(responses) > 1 {
+		c.responses[key] = responses[1:]
+	}
+	return &http.Response{
+		StatusCode: resp.status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(resp.body)),
+	}, nil
+}
+
+func TestMatrixService_WaitForMatrix(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const result = `{
+		"matrixId": "123",
+		"matrix": {"numOrigins": 1, "numDestinations": 2, "distances": [10, 20], "errorCodes": [0, 1]},
+		"regionDefinition": {"type": "world"}
+	}`
+	exp := &routingv8.CalculateMatrixResponse{
+		MatrixID: "123",
+		Matrix: routingv8.MatrixResponse{
+			NumOrigins:      1,
+			NumDestinations: 2,
+			Distances:       []int32{10, 20},
+			ErrorCodes:      routingv8.ErrorCodes{routingv8.ErrorCodeSuccess, routingv8.ErrorCodeDisconnected},
+		},
+		RegionDefinition: routingv8.RegionDefinition{
+			Type: routingv8.RegionTypeWorld,
+		},
+	}
+	for _, tt := range []struct {
+		name             string
+		responses        map[string][]mockResponse
+		expectedRequests []string
+	}{
+		{
+			name: "redirect to result",
+			responses: map[string][]mockResponse{
+				"GET /v8/matrix/123/status": {
+					{status: 200, body: `{"matrixId": "123", "status": "accepted"}`},
+					{status: 200, body: `{"matrixId": "123", "status": "inProgress"}`},
+					{
+						status: 303,
+						body: `{"matrixId": "123", "status": "completed",` +
+							`"resultUrl": "https://aws-eu-west-1.matrix.router.hereapi.com/v8/matrix/123"}`,
+					},
+				},
+				"GET /v8/matrix/123": {{status: 200, body: result}},
+			},
+			expectedRequests: []string{
+				"GET /v8/matrix/123/status",
+				"GET /v8/matrix/123/status",
+				"GET /v8/matrix/123/status",
+				"GET /v8/matrix/123",
+			},
+		},
+		{
+			name: "redirect followed by http client",
+			responses: map[string][]mockResponse{
+				"GET /v8/matrix/123/status": {
+					{status: 200, body: `{"matrixId": "123", "status": "inProgress"}`},
+					{status: 200, body: result},
+				},
+			},
+			expectedRequests: []string{
+				"GET /v8/matrix/123/status",
+				"GET /v8/matrix/123/status",
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			httpClient := AsyncMatrixMock{responses: tt.responses}
+			routingClient := routingv8.NewClient(&httpClient)
+			routingClient.MatrixPolling = routingv8.MatrixPollingBackoff{
+				InitialInterval: time.Millisecond,
+				MaxInterval:     time.Millisecond,
+			}
+			got, err := routingClient.Matrix.WaitForMatrix(ctx, "123")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, exp, got)
+			assert.DeepEqual(t, tt.expectedRequests, httpClient.requests)
+		})
+	}
+}
+
+func TestMatrixService_WaitForMatrix_Failed(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name   string
+		body   string
+		errStr []string
+	}{
+		{
+			name: "failed",
+			body: `{"matrixId": "123", "status": "failed",` +
+				`"error": {"title": "Matrix calculation failed", "status": 500, "code": "E605001"}}`,
+			errStr: []string{`"failed"`, "Matrix calculation failed", "E605001"},
+		},
+		{
+			name: "timed out",
+			body: `{"matrixId": "123", "status": "timedOut",` +
+				`"error": {"title": "Matrix calculation timed out", "status": 500, "code": "E605002"}}`,
+			errStr: []string{`"timedOut"`, "Matrix calculation timed out", "E605002"},
+		},
+		{
+			name:   "unknown status",
+			body:   `{"matrixId": "123", "status": "discarded"}`,
+			errStr: []string{`"discarded"`},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			httpClient := AsyncMatrixMock{
+				responses: map[string][]mockResponse{
+					"GET /v8/matrix/123/status": {{status: 200, body: tt.body}},
+				},
+			}
+			routingClient := routingv8.NewClient(&httpClient)
+			_, err := routingClient.Matrix.WaitForMatrix(context.Background(), "123")
+			for _, errStr := range tt.errStr {
+				assert.ErrorContains(t, err, errStr)
+			}
+		})
+	}
+}
+
+func TestMatrixService_WaitForMatrix_ContextCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	httpClient := AsyncMatrixMock{
+		responses: map[string][]mockResponse{
+			"GET /v8/matrix/123/status": {
+				{status: 200, body: `{"matrixId": "123", "status": "inProgress"}`},
+			},
+		},
+	}
+	routingClient := routingv8.NewClient(&httpClient)
+	routingClient.MatrixPolling = routingv8.MatrixPollingBackoff{InitialInterval: time.Millisecond}
+	_, err := routingClient.Matrix.WaitForMatrix(ctx, "123")
+	assert.ErrorContains(t, err, "context deadline exceeded")
+}
+
+func TestMatrixService_GetMatrixStatus(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	httpClient := AsyncMatrixMock{
+		responses: map[string][]mockResponse{
+			"GET /v8/matrix/123/status": {
+				{
+					status: 200,
+					body: `{"matrixId": "123", "status": "inProgress",` +
+						`"statusUrl": "https://matrix.router.hereapi.com/v8/matrix/123/status"}`,
+				},
+			},
+		},
+	}
+	routingClient := routingv8.NewClient(&httpClient)
+	got, err := routingClient.Matrix.GetMatrixStatus(ctx, "123")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, &routingv8.MatrixStatusResponse{
+		MatrixID:  "123",
+		Status:    routingv8.MatrixStatusInProgress,
+		StatusURL: "https://matrix.router.hereapi.com/v8/matrix/123/status",
+	}, got)
+}
+
+func TestCalculateMatrixBody_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	body := &routingv8.CalculateMatrixBody{
+		RegionDefinition: routingv8.RegionDefinition{
+			Type: routingv8.RegionTypeWorld,
+		},
+		Profile:  routingv8.ProfileTruckFast,
+		DepartAt: time.Date(2021, 11, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600)),
+	}
+	b, err := json.Marshal(body)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		`{"origins":null,"destinations":null,"departureTime":"2021-11-01T10:00:00+01:00",`+
+			`"regionDefinition":{"type":"world"},"profile":"truckFast"}`,
+		string(b),
+	)
+	body.DepartureTime = routingv8.DepartureTimeAny
+	_, err = json.Marshal(body)
+	assert.ErrorContains(t, err, "only one of DepartureTime and DepartAt can be set")
+}
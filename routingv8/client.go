@@ -8,6 +8,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
+
+	"go.einride.tech/here/internal/cache"
+	"go.einride.tech/here/internal/ratelimit"
+	"go.einride.tech/here/internal/retry"
 )
 
 const (
@@ -24,21 +29,62 @@ type MatrixService service
 // RoutingService handles communication with the routing-related methods of the HERE API.
 type RoutingService service
 
+// IsolineService handles communication with the isoline-related methods of the HERE API.
+type IsolineService service
+
+// TransitService handles communication with the public transit-related methods of the HERE API.
+type TransitService service
+
 type Client struct {
 	// HTTP client used to communicate with the API.
 	client HTTPClient
 
 	UserAgent string
 
+	// MatrixPolling configures how MatrixService.WaitForMatrix polls async matrix calculations.
+	MatrixPolling MatrixPollingBackoff
+
+	// MaxURLLength is the length of a RoutingService.Routes request URL above which the request is sent in its POST
+	// form, with the avoid and exclude parameters in the body. Defaults to 8000.
+	MaxURLLength int
+
+	// RetryPolicy configures how requests failing with a transient error are retried. Requests are not retried by
+	// default.
+	RetryPolicy RetryPolicy
+	// RetryCalculateMatrix opts in to retrying MatrixService.CalculateMatrix, which is a POST request, according to
+	// the RetryPolicy. Retrying an async matrix may start more than one calculation.
+	RetryCalculateMatrix bool
+
+	// Cache stores responses, if set. Only responses of RoutingService.Routes are cached, and served for the
+	// CacheTTL of the Routing service.
+	Cache Cache
+
+	// Offline serves responses only from the Cache, and fails with ErrOffline instead of sending a request.
+	// Only RoutingService.Routes can be used offline, all other requests fail.
+	Offline bool
+
+	// FailOnCriticalNotices makes RoutingService.Routes and RoutingService.RecalculateRoute return a
+	// *CriticalNoticeError, together with the response, if the response has notices with CriticalNoticeSeverity.
+	// E.g. a truck route violating a vehicle restriction is then returned as an error.
+	FailOnCriticalNotices bool
+
 	// Matrix service.
 	Matrix  *MatrixService
 	Routing *RoutingService
+	Isoline *IsolineService
+	Transit *TransitService
 }
 
 type service struct {
 	// URL for service API requests
 	URL    *url.URL
 	Client *Client
+	// RateLimiter limits the rate of requests to the service, if set.
+	RateLimiter *RateLimiter
+	// CacheTTL is how long responses of the service are stored in the Cache of the Client, for the methods which
+	// support caching. Responses are not served from the cache if zero, but may be stored for offline use, see
+	// Client.Offline.
+	CacheTTL time.Duration
 }
 
 // A ResponseError reports the error caused by an API request.
@@ -82,9 +128,20 @@ func NewClient(httpClient HTTPClient) *Client {
 	c.Matrix = &MatrixService{URL: matrixURL, Client: c}
 	routingURL, _ := url.Parse("https://router.hereapi.com/v8/")
 	c.Routing = &RoutingService{URL: routingURL, Client: c}
+	isolineURL, _ := url.Parse("https://isoline.router.hereapi.com/v8/")
+	c.Isoline = &IsolineService{URL: isolineURL, Client: c}
+	transitURL, _ := url.Parse("https://transit.router.hereapi.com/v8/")
+	c.Transit = &TransitService{URL: transitURL, Client: c}
 	return c
 }
 
+func (c *Client) maxURLLength() int {
+	if c.MaxURLLength > 0 {
+		return c.MaxURLLength
+	}
+	return defaultMaxURLLength
+}
+
 // NewRequest creates an API request. A relative URL can be provided in urlStr, which will be resolved to the
 // BaseURL of the Client. Relative URLS should always be specified without a preceding slash. If specified, the
 // value pointed to by body is JSON encoded and included in as the request body.
@@ -119,8 +176,18 @@ func (c *Client) NewRequest(
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
+// Requests with idempotent methods are retried according to the RetryPolicy of the client. Each attempt waits for
+// the RateLimiter of the service that created the request, if any.
 func (c *Client) Do(req *http.Request, v interface{}) error {
-	resp, err := c.client.Do(req)
+	return c.do(req, v, retry.IsIdempotent(req.Method))
+}
+
+// do is Do, but retries the request according to the RetryPolicy only if retryable is set.
+func (c *Client) do(req *http.Request, v interface{}, retryable bool) error {
+	if err := cache.CheckOffline(req, c.Offline); err != nil {
+		return err
+	}
+	resp, err := retry.Send(ratelimit.Client(c.client), req, c.RetryPolicy, retryable)
 	if err != nil {
 		return err
 	}
@@ -151,6 +218,8 @@ func (c *Client) Do(req *http.Request, v interface{}) error {
 
 // checkResponse checks the API response for errors, and returns them if present. A response is considered an
 // error if it has a status code outside the 200 range.
+// The error is a *ResponseError, also if the body is not a HERE error, in which case only HTTPBody and
+// HTTPStatusCode are set.
 func checkResponse(r *http.Response) error {
 	if c := r.StatusCode; c >= 200 && c <= 299 {
 		return nil
@@ -161,9 +230,12 @@ func checkResponse(r *http.Response) error {
 		return err
 	}
 	var response HereErrorResponse
-	err = json.Unmarshal(buf.Bytes(), &response)
-	if err != nil {
-		return err
+	if err := json.Unmarshal(buf.Bytes(), &response); err != nil {
+		// The body is not a HERE error, e.g. a redirect or an error from a proxy.
+		return &ResponseError{
+			HTTPBody:       buf.String(),
+			HTTPStatusCode: r.StatusCode,
+		}
 	}
 	return &ResponseError{
 		Response:       &response,
@@ -0,0 +1,55 @@
+package routingv8_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/internal/httpmock"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestClient_Offline(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cache, err := routingv8.NewDiskCache(t.TempDir())
+	assert.NilError(t, err)
+	routesRequest := &routingv8.RoutesRequest{
+		Origin:        routingv8.GeoWaypoint{Lat: 1, Long: 2},
+		Destination:   routingv8.GeoWaypoint{Lat: 3, Long: 4},
+		TransportMode: routingv8.TransportModeCar,
+	}
+	// Record a route departing now, which is stored but never served online.
+	mock := &httpmock.Client{
+		Body: func(*http.Request) string {
+			return `{"routes":[]}`
+		},
+	}
+	client := routingv8.NewClient(mock)
+	client.Cache = cache
+	client.Routing.CacheTTL = time.Hour
+	for i := 0; i < 2; i++ {
+		_, err = client.Routing.Routes(ctx, routesRequest)
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, 2, len(mock.Bodies()))
+	// Serve the recorded route offline.
+	cache.KeepExpired = true
+	offlineMock := &httpmock.Client{}
+	offline := routingv8.NewClient(offlineMock)
+	offline.Cache = cache
+	offline.Offline = true
+	resp, err := offline.Routing.Routes(ctx, routesRequest)
+	assert.NilError(t, err)
+	assert.Assert(t, resp != nil)
+	other := *routesRequest
+	other.Destination = routingv8.GeoWaypoint{Lat: 5, Long: 6}
+	_, err = offline.Routing.Routes(ctx, &other)
+	assert.Assert(t, errors.Is(err, routingv8.ErrOffline))
+	_, err = offline.Matrix.WaitForMatrix(ctx, "id")
+	assert.ErrorContains(t, err, routingv8.ErrOffline.Error())
+	assert.Equal(t, 0, len(offlineMock.Bodies()))
+}
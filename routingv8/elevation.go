@@ -0,0 +1,149 @@
+package routingv8
+
+import (
+	"fmt"
+	"math"
+)
+
+// earthRadius is the mean radius of the earth in meters.
+const earthRadius = 6371008.8
+
+// ElevationProfile is the elevation along a route, calculated from the elevation of its polylines.
+// Gradients are in percent, i.e. meters of elevation per 100 meters of distance, and negative downhill.
+type ElevationProfile struct {
+	// Points of the route, with the distance along the route of each point.
+	Points []ElevationPoint
+	// Sections of the route, in the same order as Route.Sections.
+	Sections []SectionElevation
+	// Ascent is the total elevation gained along the route, in meters.
+	Ascent float64
+	// Descent is the total elevation lost along the route, in meters.
+	Descent float64
+	// SteepSegments of the route, with a gradient steeper than the requested threshold.
+	SteepSegments []SteepSegment
+}
+
+// ElevationPoint is a point of an ElevationProfile.
+type ElevationPoint struct {
+	// Location of the point, including its elevation.
+	Location GeoWaypoint
+	// Distance along the route from its start, in meters.
+	Distance float64
+}
+
+// SectionElevation is the elevation summary of a section.
+type SectionElevation struct {
+	// Length of the section polyline in meters.
+	Length float64
+	// Ascent is the elevation gained along the section, in meters.
+	Ascent float64
+	// Descent is the elevation lost along the section, in meters.
+	Descent float64
+	// MaxGradient is the gradient of the steepest segment of the section, uphill or downhill.
+	MaxGradient float64
+	// AverageGradient is the net elevation change of the section divided by its length.
+	AverageGradient float64
+}
+
+// SteepSegment is a part of a route with a gradient steeper than a threshold.
+type SteepSegment struct {
+	// Section index of the segment in Route.Sections.
+	Section int
+	// StartDistance along the route from its start, in meters.
+	StartDistance float64
+	// EndDistance along the route from its start, in meters.
+	EndDistance float64
+	// Gradient of the segment, negative if downhill.
+	Gradient float64
+}
+
+// NewElevationProfile returns the elevation profile of the route. The route must be calculated with
+// PolylineReturnAttribute and ElevationReturnAttribute, so that its polylines have elevation. An error is returned
+// if any section is missing its polyline.
+//
+// Consecutive polyline segments with an absolute gradient of at least steepGradient percent in the same direction
+// are merged and returned as SteepSegments. If steepGradient is not positive, no steep segments are returned.
+func NewElevationProfile(route *Route, steepGradient float64) (*ElevationProfile, error) {
+	profile := &ElevationProfile{Sections: make([]SectionElevation, 0, len(route.Sections))}
+	var distance float64
+	for i := range route.Sections {
+		if route.Sections[i].Polyline == "" {
+			return nil, fmt.Errorf("elevation profile: section %d: missing polyline", i)
+		}
+		header, err := route.Sections[i].Polyline.Header()
+		if err != nil {
+			return nil, fmt.Errorf("elevation profile: section %d: %v", i, err)
+		}
+		if header.ThirdDimension != ThirdDimensionElevation && header.ThirdDimension != ThirdDimensionAltitude {
+			return nil, fmt.Errorf("elevation profile: section %d: polyline has no elevation", i)
+		}
+		points, err := route.Sections[i].Polyline.Decode()
+		if err != nil {
+			return nil, fmt.Errorf("elevation profile: section %d: %v", i, err)
+		}
+		var section SectionElevation
+		var steep *SteepSegment
+		for j, point := range points {
+			if j == 0 {
+				// The first point of a section is the last point of the previous section.
+				if len(profile.Points) == 0 || profile.Points[len(profile.Points)-1].Location != point {
+					profile.Points = append(profile.Points, ElevationPoint{Location: point, Distance: distance})
+				}
+				continue
+			}
+			length := haversineDistance(points[j-1], point)
+			climb := point.Elevation - points[j-1].Elevation
+			section.Length += length
+			if climb > 0 {
+				section.Ascent += climb
+			} else {
+				section.Descent -= climb
+			}
+			var gradient float64
+			if length > 0 {
+				gradient = climb / length * 100
+			}
+			if math.Abs(gradient) > math.Abs(section.MaxGradient) {
+				section.MaxGradient = gradient
+			}
+			if steepGradient > 0 && math.Abs(gradient) >= steepGradient {
+				if steep == nil || (steep.Gradient > 0) != (gradient > 0) {
+					profile.appendSteepSegment(steep)
+					steep = &SteepSegment{Section: i, StartDistance: distance}
+				}
+				steep.EndDistance = distance + length
+				steep.Gradient = (steep.Gradient*(distance-steep.StartDistance) + gradient*length) /
+					(steep.EndDistance - steep.StartDistance)
+			} else {
+				profile.appendSteepSegment(steep)
+				steep = nil
+			}
+			distance += length
+			profile.Points = append(profile.Points, ElevationPoint{Location: point, Distance: distance})
+		}
+		profile.appendSteepSegment(steep)
+		if section.Length > 0 {
+			section.AverageGradient = (section.Ascent - section.Descent) / section.Length * 100
+		}
+		profile.Ascent += section.Ascent
+		profile.Descent += section.Descent
+		profile.Sections = append(profile.Sections, section)
+	}
+	return profile, nil
+}
+
+func (p *ElevationProfile) appendSteepSegment(segment *SteepSegment) {
+	if segment != nil {
+		p.SteepSegments = append(p.SteepSegments, *segment)
+	}
+}
+
+// haversineDistance returns the great-circle distance in meters between two points.
+func haversineDistance(a, b GeoWaypoint) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (b.Long - a.Long) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
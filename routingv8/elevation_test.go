@@ -0,0 +1,96 @@
+package routingv8_test
+
+import (
+	"math"
+	"testing"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestNewElevationProfile(t *testing.T) {
+	t.Parallel()
+	header := routingv8.PolylineHeader{Precision: 5, ThirdDimension: routingv8.ThirdDimensionElevation}
+	// Points 0.001 degrees of latitude apart, i.e. about 111.195 meters.
+	first, err := routingv8.EncodePolyline(header, []routingv8.GeoWaypoint{
+		{Lat: 57.000, Long: 12, Elevation: 100},
+		{Lat: 57.001, Long: 12, Elevation: 110},
+		{Lat: 57.002, Long: 12, Elevation: 120},
+		{Lat: 57.003, Long: 12, Elevation: 118},
+		{Lat: 57.004, Long: 12, Elevation: 110},
+	})
+	assert.NilError(t, err)
+	second, err := routingv8.EncodePolyline(header, []routingv8.GeoWaypoint{
+		{Lat: 57.004, Long: 12, Elevation: 110},
+		{Lat: 57.005, Long: 12, Elevation: 110},
+	})
+	assert.NilError(t, err)
+	route := routingv8.Route{
+		Sections: []routingv8.Section{{Polyline: first}, {Polyline: second}},
+	}
+	got, err := routingv8.NewElevationProfile(&route, 6)
+	assert.NilError(t, err)
+
+	const step = 111.195
+	const steep = 10 / step * 100
+	assertApprox := func(t *testing.T, expected, actual float64) {
+		t.Helper()
+		assert.Assert(t, math.Abs(expected-actual) < 0.01, "expected %v, got %v", expected, actual)
+	}
+	assert.Equal(t, 6, len(got.Points))
+	for i, point := range got.Points {
+		assertApprox(t, float64(i)*step, point.Distance)
+	}
+	assert.Equal(t, 110.0, got.Points[5].Location.Elevation)
+	assertApprox(t, 20, got.Ascent)
+	assertApprox(t, 10, got.Descent)
+	assert.Equal(t, 2, len(got.Sections))
+	assertApprox(t, 4*step, got.Sections[0].Length)
+	assertApprox(t, 20, got.Sections[0].Ascent)
+	assertApprox(t, 10, got.Sections[0].Descent)
+	assertApprox(t, steep, got.Sections[0].MaxGradient)
+	assertApprox(t, 10/(4*step)*100, got.Sections[0].AverageGradient)
+	assertApprox(t, step, got.Sections[1].Length)
+	assertApprox(t, 0, got.Sections[1].MaxGradient)
+	assertApprox(t, 0, got.Sections[1].AverageGradient)
+	assert.Equal(t, 2, len(got.SteepSegments))
+	assert.Equal(t, 0, got.SteepSegments[0].Section)
+	assertApprox(t, 0, got.SteepSegments[0].StartDistance)
+	assertApprox(t, 2*step, got.SteepSegments[0].EndDistance)
+	assertApprox(t, steep, got.SteepSegments[0].Gradient)
+	assertApprox(t, 3*step, got.SteepSegments[1].StartDistance)
+	assertApprox(t, 4*step, got.SteepSegments[1].EndDistance)
+	assertApprox(t, -8/step*100, got.SteepSegments[1].Gradient)
+
+	withoutThreshold, err := routingv8.NewElevationProfile(&route, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(withoutThreshold.SteepSegments))
+}
+
+func TestNewElevationProfile_Error(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		sections []routingv8.Section
+		errStr   string
+	}{
+		{
+			name:     "without elevation",
+			sections: []routingv8.Section{{Polyline: "BFoz5xJ67i1B1B7PzIhaxL7Y"}},
+			errStr:   "section 0: polyline has no elevation",
+		},
+		{
+			name:     "without polyline",
+			sections: []routingv8.Section{{}},
+			errStr:   "section 0: missing polyline",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			route := routingv8.Route{Sections: tt.sections}
+			_, err := routingv8.NewElevationProfile(&route, 6)
+			assert.ErrorContains(t, err, tt.errStr)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package routingv8
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EV defines the parameters of an electric vehicle, used to calculate consumption and charging stops.
+// Charges are in kWh, powers in kW and consumptions in kWh per km, i.e. Wh per m.
+// See https://developer.here.com/documentation/routing-api/dev_guide/topics/use-cases/ev-routing.html
+// for details about EV routing.
+type EV struct {
+	// FreeFlowSpeedTable is the consumption at different speeds on a flat road without traffic. Required.
+	FreeFlowSpeedTable []ConsumptionPoint
+	// TrafficSpeedTable is the consumption at different speeds on a flat road with traffic.
+	TrafficSpeedTable []ConsumptionPoint
+	// AuxiliaryConsumption is the power used by auxiliary systems, e.g. heating, in kW.
+	AuxiliaryConsumption float64
+	// Ascent is the extra consumption per meter of elevation gained, in kWh per km.
+	Ascent float64
+	// Descent is the energy recovered per meter of elevation lost, in kWh per km.
+	Descent float64
+	// InitialCharge of the battery at departure.
+	InitialCharge float64
+	// MaxCharge is the total capacity of the battery.
+	MaxCharge float64
+	// ChargingCurve is the charging power at different charges of the battery.
+	ChargingCurve []ChargingCurvePoint
+	// MaxChargingVoltage supported by the vehicle in volts.
+	MaxChargingVoltage float64
+	// MaxChargeAfterChargingStation is the charge to stop charging at, at charging stations.
+	MaxChargeAfterChargingStation float64
+	// MinChargeAtChargingStation is the lowest charge allowed when arriving at a charging station.
+	MinChargeAtChargingStation float64
+	// MinChargeAtDestination is the lowest charge allowed when arriving at the destination.
+	MinChargeAtDestination float64
+	// ChargingSetupDuration is the time spent at a charging station before charging starts.
+	ChargingSetupDuration time.Duration
+	// ConnectorTypes supported by the vehicle.
+	ConnectorTypes []ConnectorType
+	// MakeReachable adds charging stations to the route, so that the destination can be reached.
+	// Requires InitialCharge, MaxCharge, ChargingCurve, MaxChargeAfterChargingStation,
+	// MinChargeAtChargingStation, MinChargeAtDestination and ConnectorTypes.
+	// InitialCharge and the min charges are always sent, as zero is a valid value for them.
+	MakeReachable bool
+}
+
+// ConsumptionPoint is the consumption at a given speed.
+type ConsumptionPoint struct {
+	// Speed in km/h.
+	Speed float64
+	// Consumption in kWh per km.
+	Consumption float64
+}
+
+// ChargingCurvePoint is the charging power at a given charge of the battery.
+type ChargingCurvePoint struct {
+	// Charge of the battery in kWh.
+	Charge float64
+	// Power in kW.
+	Power float64
+}
+
+type ConnectorType int
+
+const (
+	ConnectorTypeUnspecified ConnectorType = iota
+	ConnectorTypeIEC62196Type1Combo
+	ConnectorTypeIEC62196Type2Combo
+	ConnectorTypeChademo
+	ConnectorTypeTesla
+	ConnectorTypeGBTDC
+)
+
+func (c *ConnectorType) String() string {
+	switch *c {
+	case ConnectorTypeUnspecified:
+		return unspecified
+	case ConnectorTypeIEC62196Type1Combo:
+		return "iec62196Type1Combo"
+	case ConnectorTypeIEC62196Type2Combo:
+		return "iec62196Type2Combo"
+	case ConnectorTypeChademo:
+		return "chademo"
+	case ConnectorTypeTesla:
+		return "tesla"
+	case ConnectorTypeGBTDC:
+		return "gbtDc"
+	default:
+		return invalid
+	}
+}
+
+func (e *EV) validate() error {
+	if len(e.FreeFlowSpeedTable) == 0 {
+		return fmt.Errorf("ev free flow speed table is required")
+	}
+	if e.InitialCharge < 0 || e.MaxCharge < 0 || (e.MaxCharge > 0 && e.InitialCharge > e.MaxCharge) {
+		return fmt.Errorf("invalid ev initial charge %v and max charge %v", e.InitialCharge, e.MaxCharge)
+	}
+	if e.MakeReachable {
+		if e.MaxCharge == 0 ||
+			len(e.ChargingCurve) == 0 ||
+			e.MaxChargeAfterChargingStation == 0 ||
+			len(e.ConnectorTypes) == 0 {
+			return fmt.Errorf(
+				"ev make reachable requires max charge, charging curve, " +
+					"max charge after charging station and connector types",
+			)
+		}
+		for _, minCharge := range []float64{e.MinChargeAtChargingStation, e.MinChargeAtDestination} {
+			if minCharge < 0 || minCharge > e.MaxCharge {
+				return fmt.Errorf("invalid ev min charge %v, must be between 0 and max charge %v", minCharge, e.MaxCharge)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *EV) addQueryValues(values url.Values) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+	speedTable := func(points []ConsumptionPoint) string {
+		result := make([]string, 0, 2*len(points))
+		for _, p := range points {
+			result = append(result, formatFloat(p.Speed), formatFloat(p.Consumption))
+		}
+		return strings.Join(result, ",")
+	}
+	values.Add("ev[freeFlowSpeedTable]", speedTable(e.FreeFlowSpeedTable))
+	if len(e.TrafficSpeedTable) > 0 {
+		values.Add("ev[trafficSpeedTable]", speedTable(e.TrafficSpeedTable))
+	}
+	if len(e.ChargingCurve) > 0 {
+		curve := make([]string, 0, 2*len(e.ChargingCurve))
+		for _, p := range e.ChargingCurve {
+			curve = append(curve, formatFloat(p.Charge), formatFloat(p.Power))
+		}
+		values.Add("ev[chargingCurve]", strings.Join(curve, ","))
+	}
+	for _, param := range []struct {
+		key   string
+		value float64
+		// always sends the value also if it is zero.
+		always bool
+	}{
+		{key: "ev[auxiliaryConsumption]", value: e.AuxiliaryConsumption},
+		{key: "ev[ascent]", value: e.Ascent},
+		{key: "ev[descent]", value: e.Descent},
+		{key: "ev[maxCharge]", value: e.MaxCharge},
+		{key: "ev[maxChargingVoltage]", value: e.MaxChargingVoltage},
+		{key: "ev[maxChargeAfterChargingStation]", value: e.MaxChargeAfterChargingStation},
+		{key: "ev[minChargeAtChargingStation]", value: e.MinChargeAtChargingStation, always: e.MakeReachable},
+		{key: "ev[minChargeAtDestination]", value: e.MinChargeAtDestination, always: e.MakeReachable},
+	} {
+		if param.value != 0 || param.always {
+			values.Add(param.key, formatFloat(param.value))
+		}
+	}
+	if e.InitialCharge != 0 || e.MakeReachable {
+		values.Add("ev[initialCharge]", formatFloat(e.InitialCharge))
+	}
+	if e.ChargingSetupDuration > 0 {
+		values.Add("ev[chargingSetupDuration]", strconv.FormatInt(int64(e.ChargingSetupDuration/time.Second), 10))
+	}
+	if len(e.ConnectorTypes) > 0 {
+		connectorTypes := make([]string, 0, len(e.ConnectorTypes))
+		for _, connectorType := range e.ConnectorTypes {
+			c := connectorType.String()
+			if c == invalid {
+				return fmt.Errorf("invalid connector type")
+			}
+			if c != unspecified {
+				connectorTypes = append(connectorTypes, c)
+			}
+		}
+		values.Add("ev[connectorTypes]", strings.Join(connectorTypes, ","))
+	}
+	if e.MakeReachable {
+		values.Add("ev[makeReachable]", "true")
+	}
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// ChargingStops returns the sections of the route that end at a charging station, in route order.
+// The charging time and charge levels are given by the PostActions and Arrival of each section.
+func (r *Route) ChargingStops() []*Section {
+	var stops []*Section
+	for i := range r.Sections {
+		if r.Sections[i].Arrival.Place.Type == PlaceTypeChargingStation {
+			stops = append(stops, &r.Sections[i])
+		}
+	}
+	return stops
+}
@@ -0,0 +1,124 @@
+package routingv8
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.einride.tech/here/internal/ratelimit"
+)
+
+// ImportRouteRequest is a request to match a GPS trace to the road network.
+type ImportRouteRequest struct {
+	// Trace of GPS points, in the order they were recorded. At least 2 points are required.
+	Trace         []TracePoint
+	TransportMode TransportMode
+	Return        []ReturnAttribute
+	Spans         []SpanAttribute
+	// Lang is the language of instructions, as a BCP 47 language tag, e.g. "en-US".
+	Lang string
+	// Truck configuration, encoded as vehicle parameters.
+	Truck *Truck
+}
+
+// TracePoint is a recorded GPS point of a trace.
+type TracePoint struct {
+	// Location of the point.
+	Location GeoWaypoint
+	// Timestamp when the point was recorded. Optional.
+	Timestamp time.Time
+	// Heading in degrees clockwise from north. Optional.
+	Heading *float64
+	// Speed in meters per second. Optional.
+	Speed *float64
+}
+
+func (p TracePoint) MarshalJSON() ([]byte, error) {
+	point := struct {
+		Lat       float64  `json:"lat"`
+		Long      float64  `json:"lng"`
+		Timestamp string   `json:"timestamp,omitempty"`
+		Heading   *float64 `json:"heading,omitempty"`
+		Speed     *float64 `json:"speed,omitempty"`
+	}{
+		Lat:     p.Location.Lat,
+		Long:    p.Location.Long,
+		Heading: p.Heading,
+		Speed:   p.Speed,
+	}
+	if !p.Timestamp.IsZero() {
+		point.Timestamp = formatTime(p.Timestamp)
+	}
+	return json.Marshal(point)
+}
+
+// ImportRoute returns the route driven along a GPS trace, matched to the road network.
+// The sections and spans of the returned routes are the same as for routes calculated by Routes.
+// See https://developer.here.com/documentation/routing-api/dev_guide/topics/use-cases/import-route.html
+// for details about route import.
+func (s *RoutingService) ImportRoute(
+	ctx context.Context,
+	req *ImportRouteRequest,
+) (_ *RoutesResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("import route: %v", err)
+		}
+	}()
+	tm := req.TransportMode.String()
+	if tm == invalid || tm == unspecified {
+		return nil, fmt.Errorf("invalid transportmode")
+	}
+	if len(req.Trace) < 2 {
+		return nil, errors.New("InvalidArgument, trace must have at least 2 points")
+	}
+	// Timestamps are optional, points without one are not ordered.
+	var last time.Time
+	for i, point := range req.Trace {
+		if point.Timestamp.IsZero() {
+			continue
+		}
+		if point.Timestamp.Before(last) {
+			return nil, fmt.Errorf("InvalidArgument, trace point %d is earlier than the previous point", i)
+		}
+		last = point.Timestamp
+	}
+	u, err := s.URL.Parse("import")
+	if err != nil {
+		return nil, err
+	}
+	values := make(url.Values)
+	values.Add("transportMode", tm)
+	if err := addReturnQueryValues(values, req.Return, req.Spans); err != nil {
+		return nil, err
+	}
+	if req.Lang != "" {
+		values.Add("lang", req.Lang)
+	}
+	if req.Truck != nil {
+		if err := req.Truck.addQueryValues(values); err != nil {
+			return nil, err
+		}
+	}
+	body, err := json.Marshal(struct {
+		Trace []TracePoint `json:"trace"`
+	}{
+		Trace: req.Trace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodPost, values.Encode(), body)
+	if err != nil {
+		return nil, err
+	}
+	var resp RoutesResponse
+	if err := s.Client.Do(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,168 @@
+package routingv8_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+type ImportRouteMock struct {
+	request      *http.Request
+	requestBody  string
+	responseBody routingv8.RoutesResponse
+}
+
+func (c *ImportRouteMock) Do(req *http.Request) (*http.Response, error) {
+	c.request = req
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.requestBody = string(body)
+	b, err := json.Marshal(c.responseBody)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(b)),
+		ContentLength: int64(len(b)),
+	}, nil
+}
+
+func TestRoutingService_ImportRoute(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	heading := 90.0
+	speed := 13.5
+	exp := routingv8.RoutesResponse{
+		Routes: []routingv8.Route{
+			{
+				ID: "1",
+				Sections: []routingv8.Section{
+					{
+						ID:       "2",
+						Type:     "vehicle",
+						Summary:  routingv8.Summary{Duration: 120, Length: 1500},
+						Polyline: "BFoz5xJ67i1B1B7PzIhaxL7Y",
+					},
+				},
+			},
+		},
+	}
+	client := ImportRouteMock{responseBody: exp}
+	routingClient := routingv8.NewClient(&client)
+	got, err := routingClient.Routing.ImportRoute(ctx, &routingv8.ImportRouteRequest{
+		Trace: []routingv8.TracePoint{
+			{
+				Location:  routingv8.GeoWaypoint{Lat: 57.707752, Long: 11.949767},
+				Timestamp: time.Date(2021, 11, 1, 8, 0, 0, 0, time.UTC),
+				Heading:   &heading,
+				Speed:     &speed,
+			},
+			{
+				// Points without timestamp are not checked for order.
+				Location: routingv8.GeoWaypoint{Lat: 57.7079, Long: 11.9499},
+			},
+			{
+				Location:  routingv8.GeoWaypoint{Lat: 57.708, Long: 11.95},
+				Timestamp: time.Date(2021, 11, 1, 8, 0, 10, 0, time.UTC),
+			},
+		},
+		TransportMode: routingv8.TransportModeTruck,
+		Return:        []routingv8.ReturnAttribute{routingv8.SummaryReturnAttribute, routingv8.PolylineReturnAttribute},
+		Spans:         []routingv8.SpanAttribute{routingv8.SpanAttributeSegmentID},
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, &exp, got)
+	assert.Equal(t, http.MethodPost, client.request.Method)
+	assert.Equal(t, "https://router.hereapi.com/v8/import", client.request.URL.Scheme+"://"+
+		client.request.URL.Host+client.request.URL.Path)
+	assert.Equal(t, "return=summary%2Cpolyline&spans=segmentId&transportMode=truck", client.request.URL.RawQuery)
+	assert.Equal(
+		t,
+		`{"trace":[`+
+			`{"lat":57.707752,"lng":11.949767,"timestamp":"2021-11-01T08:00:00Z","heading":90,"speed":13.5},`+
+			`{"lat":57.7079,"lng":11.9499},`+
+			`{"lat":57.708,"lng":11.95,"timestamp":"2021-11-01T08:00:10Z"}]}`,
+		client.requestBody,
+	)
+}
+
+func TestRoutingService_ImportRoute_Error(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	point := func(lat float64, timestamp time.Time) routingv8.TracePoint {
+		return routingv8.TracePoint{
+			Location:  routingv8.GeoWaypoint{Lat: lat, Long: 11.949767},
+			Timestamp: timestamp,
+		}
+	}
+	start := time.Date(2021, 11, 1, 8, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name    string
+		request *routingv8.ImportRouteRequest
+		errStr  string
+	}{
+		{
+			name: "without transport mode",
+			request: &routingv8.ImportRouteRequest{
+				Trace: []routingv8.TracePoint{point(57.7, start), point(57.8, start.Add(time.Second))},
+			},
+			errStr: "invalid transportmode",
+		},
+		{
+			name: "with single point",
+			request: &routingv8.ImportRouteRequest{
+				Trace:         []routingv8.TracePoint{point(57.7, start)},
+				TransportMode: routingv8.TransportModeCar,
+			},
+			errStr: "trace must have at least 2 points",
+		},
+		{
+			name: "with unordered points",
+			request: &routingv8.ImportRouteRequest{
+				Trace:         []routingv8.TracePoint{point(57.7, start), point(57.8, start.Add(-time.Second))},
+				TransportMode: routingv8.TransportModeCar,
+			},
+			errStr: "trace point 1 is earlier than the previous point",
+		},
+		{
+			name: "with unordered points around a point without timestamp",
+			request: &routingv8.ImportRouteRequest{
+				Trace: []routingv8.TracePoint{
+					point(57.7, start), point(57.8, time.Time{}), point(57.9, start.Add(-time.Second)),
+				},
+				TransportMode: routingv8.TransportModeCar,
+			},
+			errStr: "trace point 2 is earlier than the previous point",
+		},
+		{
+			name: "with spans without polyline",
+			request: &routingv8.ImportRouteRequest{
+				Trace:         []routingv8.TracePoint{point(57.7, start), point(57.8, start.Add(time.Second))},
+				TransportMode: routingv8.TransportModeCar,
+				Spans:         []routingv8.SpanAttribute{routingv8.SpanAttributeSegmentID},
+			},
+			errStr: "spans parameter also requires that the polyline option is set",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := ImportRouteMock{}
+			routingClient := routingv8.NewClient(&client)
+			_, err := routingClient.Routing.ImportRoute(ctx, tt.request)
+			assert.ErrorContains(t, err, tt.errStr)
+			assert.Assert(t, client.request == nil)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package routingv8
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.einride.tech/here/internal/ratelimit"
+)
+
+// IsolinesRequest is a request for the areas reachable from an origin, or from which a destination is reachable,
+// within a set of time or distance ranges.
+type IsolinesRequest struct {
+	// Origin to calculate the isolines from, i.e. the areas reachable when departing from the origin.
+	// Mutually exclusive with Destination.
+	Origin *GeoWaypoint
+	// Destination to calculate the isolines to, i.e. the areas from which the destination is reachable.
+	// Mutually exclusive with Origin.
+	Destination *GeoWaypoint
+	// TimeRanges to calculate isolines for. Mutually exclusive with DistanceRanges.
+	TimeRanges []time.Duration
+	// DistanceRanges in meters to calculate isolines for. Mutually exclusive with TimeRanges.
+	DistanceRanges []int
+	TransportMode  TransportMode
+	RoutingMode    RoutingMode
+	// DepartAt is the time of departure from the origin. Only used with Origin.
+	DepartAt time.Time
+	// ArriveAt is the time of arrival at the destination. Only used with Destination.
+	ArriveAt time.Time
+	// Truck configuration, encoded as vehicle parameters.
+	Truck *Truck
+}
+
+// IsolinesResponse contains the isolines of each requested range.
+type IsolinesResponse struct {
+	// Departure from the origin. Only set for isolines with an origin.
+	Departure *VehicleDeparture `json:"departure"`
+	// Arrival at the destination. Only set for isolines with a destination.
+	Arrival *VehicleDeparture `json:"arrival"`
+	// Isolines in the order of the requested ranges.
+	Isolines []Isoline `json:"isolines"`
+	// Contains a list of issues related to this isoline calculation.
+	Notices []Notice `json:"notices"`
+}
+
+// Isoline is the reachable area of a single range.
+type Isoline struct {
+	// Range of the isoline.
+	Range IsolineRange `json:"range"`
+	// Polygons of the reachable area. An isoline consists of multiple polygons if the reachable area is not
+	// connected, e.g. across a ferry.
+	Polygons []IsolinePolygon `json:"polygons"`
+}
+
+// IsolineRange is the range of an isoline.
+type IsolineRange struct {
+	// Type of the range, "time" or "distance".
+	Type string `json:"type"`
+	// Value of the range, in seconds for time ranges and in meters for distance ranges.
+	Value int `json:"value"`
+}
+
+// IsolinePolygon is a polygon of an isoline, decoded from its flexible polylines.
+type IsolinePolygon struct {
+	// Outer ring of the polygon.
+	Outer []GeoWaypoint
+	// Inner rings of the polygon, i.e. holes of areas which are not reachable.
+	Inner [][]GeoWaypoint
+}
+
+func (p *IsolinePolygon) UnmarshalJSON(b []byte) error {
+	var polygon struct {
+		Outer Polyline   `json:"outer"`
+		Inner []Polyline `json:"inner"`
+	}
+	if err := json.Unmarshal(b, &polygon); err != nil {
+		return err
+	}
+	outer, err := polygon.Outer.Decode()
+	if err != nil {
+		return err
+	}
+	var inner [][]GeoWaypoint
+	for _, polyline := range polygon.Inner {
+		points, err := polyline.Decode()
+		if err != nil {
+			return err
+		}
+		inner = append(inner, points)
+	}
+	*p = IsolinePolygon{Outer: outer, Inner: inner}
+	return nil
+}
+
+// CalculateIsolines returns the areas reachable within each of the requested ranges.
+// See https://developer.here.com/documentation/isoline-routing-api/dev_guide/index.html
+// for details about other parameters.
+func (s *IsolineService) CalculateIsolines(
+	ctx context.Context,
+	req *IsolinesRequest,
+) (_ *IsolinesResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("calculate isolines: %v", err)
+		}
+	}()
+	values, err := req.queryValues()
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.URL.Parse("isolines")
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodGet, values.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp IsolinesResponse
+	if err := s.Client.Do(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (r *IsolinesRequest) queryValues() (url.Values, error) {
+	tm := r.TransportMode.String()
+	if tm == invalid || tm == unspecified {
+		return nil, fmt.Errorf("invalid transportmode")
+	}
+	values := make(url.Values)
+	values.Add("transportMode", tm)
+	switch {
+	case r.Origin != nil && r.Destination != nil:
+		return nil, errors.New("InvalidArgument, only one of origin and destination can be set")
+	case r.Origin != nil:
+		values.Add("origin", fmt.Sprintf("%v,%v", r.Origin.Lat, r.Origin.Long))
+		if !r.ArriveAt.IsZero() {
+			return nil, errors.New("InvalidArgument, arrival time requires a destination")
+		}
+		if !r.DepartAt.IsZero() {
+			values.Add("departureTime", formatTime(r.DepartAt))
+		}
+	case r.Destination != nil:
+		values.Add("destination", fmt.Sprintf("%v,%v", r.Destination.Lat, r.Destination.Long))
+		if !r.DepartAt.IsZero() {
+			return nil, errors.New("InvalidArgument, departure time requires an origin")
+		}
+		if !r.ArriveAt.IsZero() {
+			values.Add("arrivalTime", formatTime(r.ArriveAt))
+		}
+	default:
+		return nil, errors.New("InvalidArgument, origin or destination must be provided")
+	}
+	ranges := make([]string, 0, len(r.TimeRanges)+len(r.DistanceRanges))
+	switch {
+	case len(r.TimeRanges) > 0 && len(r.DistanceRanges) > 0:
+		return nil, errors.New("InvalidArgument, only one of time ranges and distance ranges can be set")
+	case len(r.TimeRanges) > 0:
+		values.Add("range[type]", "time")
+		for _, d := range r.TimeRanges {
+			if d < time.Second {
+				return nil, fmt.Errorf("InvalidArgument, invalid time range %v", d)
+			}
+			ranges = append(ranges, strconv.FormatInt(int64(d/time.Second), 10))
+		}
+	case len(r.DistanceRanges) > 0:
+		values.Add("range[type]", "distance")
+		for _, distance := range r.DistanceRanges {
+			if distance <= 0 {
+				return nil, fmt.Errorf("InvalidArgument, invalid distance range %d", distance)
+			}
+			ranges = append(ranges, strconv.Itoa(distance))
+		}
+	default:
+		return nil, errors.New("InvalidArgument, time ranges or distance ranges must be provided")
+	}
+	values.Add("range[values]", strings.Join(ranges, ","))
+	rm := r.RoutingMode.String()
+	if rm == invalid {
+		return nil, fmt.Errorf("invalid routingmode")
+	}
+	if rm != unspecified {
+		values.Add("routingMode", rm)
+	}
+	if r.Truck != nil {
+		if err := r.Truck.addQueryValues(values); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
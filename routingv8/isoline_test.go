@@ -0,0 +1,182 @@
+package routingv8_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+type FileMock struct {
+	requestPath     string
+	requestRawQuery string
+	responseFile    string
+}
+
+func (c *FileMock) Do(req *http.Request) (*http.Response, error) {
+	c.requestPath = req.URL.Path
+	c.requestRawQuery = req.URL.RawQuery
+	b, err := os.ReadFile(path.Join("testdata", c.responseFile))
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(b)),
+		ContentLength: int64(len(b)),
+	}, nil
+}
+
+func TestIsolineService_CalculateIsolines(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := FileMock{responseFile: "isolines.json"}
+	routingClient := routingv8.NewClient(&client)
+	got, err := routingClient.Isoline.CalculateIsolines(ctx, &routingv8.IsolinesRequest{
+		Origin:        &routingv8.GeoWaypoint{Lat: 57.707752, Long: 11.949767},
+		TimeRanges:    []time.Duration{2 * time.Hour, 4 * time.Hour},
+		TransportMode: routingv8.TransportModeTruck,
+		DepartAt:      time.Date(2021, 11, 1, 7, 0, 0, 0, time.UTC),
+		Truck:         &routingv8.Truck{GrossWeight: 40000},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, "/v8/isolines", client.requestPath)
+	assert.Equal(
+		t,
+		"departureTime=2021-11-01T07%3A00%3A00Z&origin=57.707752%2C11.949767"+
+			"&range%5Btype%5D=time&range%5Bvalues%5D=7200%2C14400&transportMode=truck"+
+			"&vehicle%5BgrossWeight%5D=40000",
+		client.requestRawQuery,
+	)
+	points := []routingv8.GeoWaypoint{
+		{Lat: 50.10228, Long: 8.69821},
+		{Lat: 50.10201, Long: 8.69567},
+		{Lat: 50.10063, Long: 8.69150},
+		{Lat: 50.09878, Long: 8.68752},
+	}
+	assert.Assert(t, got.Departure != nil)
+	assert.Assert(t, got.Arrival == nil)
+	assert.DeepEqual(t, []routingv8.Isoline{
+		{
+			Range:    routingv8.IsolineRange{Type: "time", Value: 7200},
+			Polygons: []routingv8.IsolinePolygon{{Outer: points}},
+		},
+		{
+			Range: routingv8.IsolineRange{Type: "time", Value: 14400},
+			Polygons: []routingv8.IsolinePolygon{
+				{Outer: points, Inner: [][]routingv8.GeoWaypoint{points}},
+				{Outer: points[:3]},
+			},
+		},
+	}, got.Isolines)
+}
+
+func TestIsolineService_CalculateIsolines_QueryParams(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	location := &routingv8.GeoWaypoint{Lat: 57.707752, Long: 11.949767}
+	for _, tt := range []struct {
+		name     string
+		request  *routingv8.IsolinesRequest
+		expected string
+		errStr   string
+	}{
+		{
+			name: "distance ranges to destination",
+			request: &routingv8.IsolinesRequest{
+				Destination:    location,
+				DistanceRanges: []int{50000, 100000},
+				TransportMode:  routingv8.TransportModeCar,
+				RoutingMode:    routingv8.RoutingModeShort,
+				ArriveAt:       time.Date(2021, 11, 1, 9, 0, 0, 0, time.UTC),
+			},
+			expected: "arrivalTime=2021-11-01T09%3A00%3A00Z&destination=57.707752%2C11.949767" +
+				"&range%5Btype%5D=distance&range%5Bvalues%5D=50000%2C100000&routingMode=short&transportMode=car",
+		},
+		{
+			name: "without origin or destination",
+			request: &routingv8.IsolinesRequest{
+				TimeRanges:    []time.Duration{time.Hour},
+				TransportMode: routingv8.TransportModeCar,
+			},
+			errStr: "origin or destination must be provided",
+		},
+		{
+			name: "with origin and destination",
+			request: &routingv8.IsolinesRequest{
+				Origin:        location,
+				Destination:   location,
+				TimeRanges:    []time.Duration{time.Hour},
+				TransportMode: routingv8.TransportModeCar,
+			},
+			errStr: "only one of origin and destination can be set",
+		},
+		{
+			name: "with arrival time from origin",
+			request: &routingv8.IsolinesRequest{
+				Origin:        location,
+				TimeRanges:    []time.Duration{time.Hour},
+				TransportMode: routingv8.TransportModeCar,
+				ArriveAt:      time.Date(2021, 11, 1, 9, 0, 0, 0, time.UTC),
+			},
+			errStr: "arrival time requires a destination",
+		},
+		{
+			name: "without ranges",
+			request: &routingv8.IsolinesRequest{
+				Origin:        location,
+				TransportMode: routingv8.TransportModeCar,
+			},
+			errStr: "time ranges or distance ranges must be provided",
+		},
+		{
+			name: "with time and distance ranges",
+			request: &routingv8.IsolinesRequest{
+				Origin:         location,
+				TimeRanges:     []time.Duration{time.Hour},
+				DistanceRanges: []int{1000},
+				TransportMode:  routingv8.TransportModeCar,
+			},
+			errStr: "only one of time ranges and distance ranges can be set",
+		},
+		{
+			name: "with invalid time range",
+			request: &routingv8.IsolinesRequest{
+				Origin:        location,
+				TimeRanges:    []time.Duration{time.Millisecond},
+				TransportMode: routingv8.TransportModeCar,
+			},
+			errStr: "invalid time range",
+		},
+		{
+			name: "without transport mode",
+			request: &routingv8.IsolinesRequest{
+				Origin:     location,
+				TimeRanges: []time.Duration{time.Hour},
+			},
+			errStr: "invalid transportmode",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := FileMock{responseFile: "isolines.json"}
+			routingClient := routingv8.NewClient(&client)
+			_, err := routingClient.Isoline.CalculateIsolines(ctx, tt.request)
+			if tt.errStr != "" {
+				assert.ErrorContains(t, err, tt.errStr)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.Equal(t, tt.expected, client.requestRawQuery)
+		})
+	}
+}
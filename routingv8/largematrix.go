@@ -0,0 +1,237 @@
+package routingv8
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultLargeMatrixMaxOrigins      = 15
+	defaultLargeMatrixMaxDestinations = 100
+	defaultLargeMatrixConcurrency     = 4
+)
+
+type CalculateLargeMatrixRequest struct {
+	// Async calculates each tile as an async matrix and waits for its result.
+	// See https://developer.here.com/documentation/matrix-routing-api/8.6.0/dev_guide/topics/modes/modes.html
+	// for the sizes of matrices allowed in sync and async mode.
+	Async Async
+	// Body is the template of the request for each tile. The origins and destinations are split into tiles,
+	// all other parameters are passed on as is.
+	// If no destinations are given, the origins are used as destinations.
+	Body *CalculateMatrixBody
+	// MaxOrigins in a single tile. Defaults to 15.
+	MaxOrigins int
+	// MaxDestinations in a single tile. Defaults to 100.
+	MaxDestinations int
+	// Concurrency is the maximum number of tiles calculated at the same time. Defaults to 4.
+	Concurrency int
+}
+
+// MatrixTile is a part of a large matrix, calculated in a single request.
+type MatrixTile struct {
+	// OriginOffset is the index of the first origin of the tile in the large matrix.
+	OriginOffset int
+	// NumOrigins in the tile.
+	NumOrigins int
+	// DestinationOffset is the index of the first destination of the tile in the large matrix.
+	DestinationOffset int
+	// NumDestinations in the tile.
+	NumDestinations int
+}
+
+// MatrixTileError reports the error of a single tile of a large matrix.
+type MatrixTileError struct {
+	// Tile that failed.
+	Tile MatrixTile
+	// Err that caused the tile to fail.
+	Err error
+}
+
+func (e *MatrixTileError) Error() string {
+	return fmt.Sprintf(
+		"tile origins [%d, %d) destinations [%d, %d): %v",
+		e.Tile.OriginOffset,
+		e.Tile.OriginOffset+e.Tile.NumOrigins,
+		e.Tile.DestinationOffset,
+		e.Tile.DestinationOffset+e.Tile.NumDestinations,
+		e.Err,
+	)
+}
+
+func (e *MatrixTileError) Unwrap() error {
+	return e.Err
+}
+
+// A LargeMatrixError reports the tiles that failed in a large matrix calculation.
+type LargeMatrixError struct {
+	// NumTiles is the total number of tiles in the matrix.
+	NumTiles int
+	// Tiles that failed, ordered by origin and destination offset.
+	Tiles []*MatrixTileError
+}
+
+func (e *LargeMatrixError) Error() string {
+	tiles := make([]string, 0, len(e.Tiles))
+	for _, tile := range e.Tiles {
+		tiles = append(tiles, tile.Error())
+	}
+	return fmt.Sprintf("%d of %d tiles failed: %s", len(e.Tiles), e.NumTiles, strings.Join(tiles, "; "))
+}
+
+// CalculateLargeMatrix calculates a matrix larger than allowed in a single request, by splitting the origins and
+// destinations into tiles and calculating them concurrently. The tiles are stitched back into a single matrix,
+// indexed by the original origins and destinations.
+//
+// If some of the tiles fail, the entries of the failed tiles have ErrorCodeUnknown in the returned matrix, and the
+// returned error is a *LargeMatrixError reporting which tiles failed.
+func (s *MatrixService) CalculateLargeMatrix(
+	ctx context.Context,
+	req *CalculateLargeMatrixRequest,
+) (*MatrixResponse, error) {
+	if req.Body == nil || len(req.Body.Origins) == 0 {
+		return nil, fmt.Errorf("calculate large matrix: InvalidArgument, origins must be provided")
+	}
+	origins := req.Body.Origins
+	destinations := req.Body.Destinations
+	if len(destinations) == 0 {
+		destinations = origins
+	}
+	maxOrigins := req.MaxOrigins
+	if maxOrigins <= 0 {
+		maxOrigins = defaultLargeMatrixMaxOrigins
+	}
+	maxDestinations := req.MaxDestinations
+	if maxDestinations <= 0 {
+		maxDestinations = defaultLargeMatrixMaxDestinations
+	}
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultLargeMatrixConcurrency
+	}
+	var tiles []MatrixTile
+	for i := 0; i < len(origins); i += maxOrigins {
+		for j := 0; j < len(destinations); j += maxDestinations {
+			tiles = append(tiles, MatrixTile{
+				OriginOffset:      i,
+				NumOrigins:        minInt(maxOrigins, len(origins)-i),
+				DestinationOffset: j,
+				NumDestinations:   minInt(maxDestinations, len(destinations)-j),
+			})
+		}
+	}
+	results := make([]*MatrixResponse, len(tiles))
+	errs := make([]error, len(tiles))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for k := range tiles {
+		k := k
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				errs[k] = ctx.Err()
+				return
+			}
+			tile := tiles[k]
+			body := *req.Body
+			body.Origins = origins[tile.OriginOffset : tile.OriginOffset+tile.NumOrigins]
+			body.Destinations = destinations[tile.DestinationOffset : tile.DestinationOffset+tile.NumDestinations]
+			results[k], errs[k] = s.calculateMatrixTile(ctx, req.Async, &body)
+		}()
+	}
+	wg.Wait()
+	resp := &MatrixResponse{
+		NumOrigins:      len(origins),
+		NumDestinations: len(destinations),
+	}
+	var largeMatrixError *LargeMatrixError
+	for k, tile := range tiles {
+		if errs[k] == nil && !tile.matches(results[k]) {
+			errs[k] = fmt.Errorf(
+				"unexpected tile size %dx%d, expected %dx%d",
+				results[k].NumOrigins,
+				results[k].NumDestinations,
+				tile.NumOrigins,
+				tile.NumDestinations,
+			)
+		}
+		if errs[k] != nil {
+			if largeMatrixError == nil {
+				largeMatrixError = &LargeMatrixError{NumTiles: len(tiles)}
+			}
+			largeMatrixError.Tiles = append(largeMatrixError.Tiles, &MatrixTileError{Tile: tile, Err: errs[k]})
+		}
+		resp.stitch(tile, results[k], errs[k])
+	}
+	if largeMatrixError != nil {
+		return resp, fmt.Errorf("calculate large matrix: %w", largeMatrixError)
+	}
+	return resp, nil
+}
+
+func (s *MatrixService) calculateMatrixTile(
+	ctx context.Context,
+	async Async,
+	body *CalculateMatrixBody,
+) (*MatrixResponse, error) {
+	resp, err := s.CalculateMatrix(ctx, &CalculateMatrixRequest{Async: async, Body: body})
+	if err != nil {
+		return nil, err
+	}
+	if async {
+		if resp, err = s.WaitForMatrix(ctx, resp.MatrixID); err != nil {
+			return nil, err
+		}
+	}
+	return &resp.Matrix, nil
+}
+
+func (t MatrixTile) matches(m *MatrixResponse) bool {
+	return m.NumOrigins == t.NumOrigins && m.NumDestinations == t.NumDestinations
+}
+
+// stitch copies the entries of a tile into the large matrix, or marks them as failed if err is set.
+func (m *MatrixResponse) stitch(tile MatrixTile, result *MatrixResponse, err error) {
+	size := m.NumOrigins * m.NumDestinations
+	if err == nil && len(result.TravelTimes) > 0 && m.TravelTimes == nil {
+		m.TravelTimes = make([]int32, size)
+	}
+	if err == nil && len(result.Distances) > 0 && m.Distances == nil {
+		m.Distances = make([]int32, size)
+	}
+	if (err != nil || len(result.ErrorCodes) > 0) && m.ErrorCodes == nil {
+		m.ErrorCodes = make(ErrorCodes, size)
+	}
+	for i := 0; i < tile.NumOrigins; i++ {
+		for j := 0; j < tile.NumDestinations; j++ {
+			k := (tile.OriginOffset+i)*m.NumDestinations + tile.DestinationOffset + j
+			if err != nil {
+				m.ErrorCodes[k] = ErrorCodeUnknown
+				continue
+			}
+			l := i*tile.NumDestinations + j
+			if l < len(result.TravelTimes) {
+				m.TravelTimes[k] = result.TravelTimes[l]
+			}
+			if l < len(result.Distances) {
+				m.Distances[k] = result.Distances[l]
+			}
+			if l < len(result.ErrorCodes) {
+				m.ErrorCodes[k] = result.ErrorCodes[l]
+			}
+		}
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,144 @@
+package routingv8_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+// TiledMatrixMock calculates travel times as 1000*origin.Lat + destination.Long.
+type TiledMatrixMock struct {
+	mu         sync.Mutex
+	numTiles   int
+	failOrigin float64
+}
+
+func (c *TiledMatrixMock) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.numTiles++
+	c.mu.Unlock()
+	var body struct {
+		Origins      []routingv8.GeoWaypoint `json:"origins"`
+		Destinations []routingv8.GeoWaypoint `json:"destinations"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	if body.Origins[0].Lat == c.failOrigin {
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       io.NopCloser(strings.NewReader(`{"title": "Internal error", "status": 500}`)),
+		}, nil
+	}
+	resp := routingv8.CalculateMatrixResponse{
+		Matrix: routingv8.MatrixResponse{
+			NumOrigins:      len(body.Origins),
+			NumDestinations: len(body.Destinations),
+		},
+		RegionDefinition: routingv8.RegionDefinition{
+			Type: routingv8.RegionTypeWorld,
+		},
+	}
+	for _, origin := range body.Origins {
+		for _, destination := range body.Destinations {
+			resp.Matrix.TravelTimes = append(resp.Matrix.TravelTimes, int32(1000*origin.Lat+destination.Long))
+		}
+	}
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(string(b))),
+	}, nil
+}
+
+func TestMatrixService_CalculateLargeMatrix(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	origins := make([]*routingv8.GeoWaypoint, 0, 7)
+	for i := 0; i < 7; i++ {
+		origins = append(origins, &routingv8.GeoWaypoint{Lat: float64(i)})
+	}
+	destinations := make([]*routingv8.GeoWaypoint, 0, 5)
+	for j := 0; j < 5; j++ {
+		destinations = append(destinations, &routingv8.GeoWaypoint{Long: float64(j)})
+	}
+
+	t.Run("all tiles succeed", func(t *testing.T) {
+		t.Parallel()
+		httpClient := TiledMatrixMock{failOrigin: -1}
+		routingClient := routingv8.NewClient(&httpClient)
+		got, err := routingClient.Matrix.CalculateLargeMatrix(ctx, &routingv8.CalculateLargeMatrixRequest{
+			Body: &routingv8.CalculateMatrixBody{
+				Origins:      origins,
+				Destinations: destinations,
+			},
+			MaxOrigins:      3,
+			MaxDestinations: 2,
+			Concurrency:     2,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, 9, httpClient.numTiles)
+		assert.Equal(t, 7, got.NumOrigins)
+		assert.Equal(t, 5, got.NumDestinations)
+		assert.Check(t, got.ErrorCodes == nil)
+		m := routingv8.NewMatrix(got)
+		for i := 0; i < 7; i++ {
+			for j := 0; j < 5; j++ {
+				travelTime, ok := m.TravelTime(i, j)
+				assert.Check(t, ok)
+				assert.Equal(t, int(travelTime.Seconds()), 1000*i+j)
+			}
+		}
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		t.Parallel()
+		httpClient := TiledMatrixMock{failOrigin: 3}
+		routingClient := routingv8.NewClient(&httpClient)
+		got, err := routingClient.Matrix.CalculateLargeMatrix(ctx, &routingv8.CalculateLargeMatrixRequest{
+			Body: &routingv8.CalculateMatrixBody{
+				Origins:      origins,
+				Destinations: destinations,
+			},
+			MaxOrigins:      3,
+			MaxDestinations: 3,
+		})
+		var largeMatrixError *routingv8.LargeMatrixError
+		assert.Assert(t, errors.As(err, &largeMatrixError))
+		assert.Equal(t, 6, largeMatrixError.NumTiles)
+		assert.DeepEqual(t, []routingv8.MatrixTile{
+			{OriginOffset: 3, NumOrigins: 3, DestinationOffset: 0, NumDestinations: 3},
+			{OriginOffset: 3, NumOrigins: 3, DestinationOffset: 3, NumDestinations: 2},
+		}, []routingv8.MatrixTile{largeMatrixError.Tiles[0].Tile, largeMatrixError.Tiles[1].Tile})
+		assert.ErrorContains(t, largeMatrixError.Tiles[0], "Internal error")
+		m := routingv8.NewMatrix(got)
+		assert.Check(t, errors.Is(m.Err(4, 4), routingv8.ErrUnknown))
+		assert.NilError(t, m.Err(6, 4))
+		travelTime, ok := m.TravelTime(6, 4)
+		assert.Check(t, ok)
+		assert.Equal(t, int(travelTime.Seconds()), 6004)
+	})
+
+	t.Run("destinations default to origins", func(t *testing.T) {
+		t.Parallel()
+		httpClient := TiledMatrixMock{failOrigin: -1}
+		routingClient := routingv8.NewClient(&httpClient)
+		got, err := routingClient.Matrix.CalculateLargeMatrix(ctx, &routingv8.CalculateLargeMatrixRequest{
+			Body: &routingv8.CalculateMatrixBody{Origins: origins},
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, 1, httpClient.numTiles)
+		assert.Equal(t, 7, got.NumDestinations)
+	})
+}
@@ -0,0 +1,155 @@
+package routingv8
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrDisconnected is returned when the origin and destination of a matrix entry are not connected.
+	ErrDisconnected = errors.New("origin and destination are not connected")
+	// ErrMatchingFailed is returned when the origin or destination of a matrix entry could not be matched to the
+	// road network.
+	ErrMatchingFailed = errors.New("matching of origin or destination failed")
+	// ErrParameterViolation is returned when the route of a matrix entry violates the request parameters.
+	ErrParameterViolation = errors.New("route violates request parameters")
+	// ErrUnknown is returned when the route of a matrix entry failed for an unknown reason.
+	ErrUnknown = errors.New("unknown error")
+)
+
+// Err returns the error for the error code, or nil if the code is ErrorCodeSuccess.
+func (e ErrorCode) Err() error {
+	switch e {
+	case ErrorCodeSuccess:
+		return nil
+	case ErrorCodeDisconnected:
+		return ErrDisconnected
+	case ErrorCodeMatchingFailed:
+		return ErrMatchingFailed
+	case ErrorCodeParameterViolation:
+		return ErrParameterViolation
+	case ErrorCodeUnknown:
+		return ErrUnknown
+	default:
+		return fmt.Errorf("%w: error code %d", ErrUnknown, int(e))
+	}
+}
+
+// Matrix is a view over a MatrixResponse, indexed by origin and destination.
+// It works for any shape of matrix, as the values of a MatrixResponse are always stored in row-major order.
+// Methods panic if an index is out of range.
+type Matrix struct {
+	response   *MatrixResponse
+	transposed bool
+}
+
+// MatrixCell holds the values of a single matrix entry.
+type MatrixCell struct {
+	// Origin index of the entry.
+	Origin int
+	// Destination index of the entry.
+	Destination int
+	// TravelTime of the route. Zero if not requested or if Err is set.
+	TravelTime time.Duration
+	// Distance of the route in meters. Zero if not requested or if Err is set.
+	Distance int32
+	// Err is the error of the route, if any.
+	Err error
+}
+
+// NewMatrix returns a Matrix view over the given response.
+func NewMatrix(response *MatrixResponse) Matrix {
+	return Matrix{response: response}
+}
+
+// NumOrigins returns the number of origins, i.e. rows, in the matrix.
+func (m Matrix) NumOrigins() int {
+	if m.transposed {
+		return m.response.NumDestinations
+	}
+	return m.response.NumOrigins
+}
+
+// NumDestinations returns the number of destinations, i.e. columns, in the matrix.
+func (m Matrix) NumDestinations() int {
+	if m.transposed {
+		return m.response.NumOrigins
+	}
+	return m.response.NumDestinations
+}
+
+// Transpose returns a view of the matrix with origins and destinations swapped.
+// The underlying response is shared and not copied.
+func (m Matrix) Transpose() Matrix {
+	return Matrix{response: m.response, transposed: !m.transposed}
+}
+
+// TravelTime returns the travel time from origin i to destination j.
+// The boolean is false if travel times were not requested or if the entry has an error.
+func (m Matrix) TravelTime(i, j int) (time.Duration, bool) {
+	k := m.index(i, j)
+	if k >= len(m.response.TravelTimes) || m.errorCode(k) != ErrorCodeSuccess {
+		return 0, false
+	}
+	return time.Duration(m.response.TravelTimes[k]) * time.Second, true
+}
+
+// Distance returns the distance in meters from origin i to destination j.
+// The boolean is false if distances were not requested or if the entry has an error.
+func (m Matrix) Distance(i, j int) (int32, bool) {
+	k := m.index(i, j)
+	if k >= len(m.response.Distances) || m.errorCode(k) != ErrorCodeSuccess {
+		return 0, false
+	}
+	return m.response.Distances[k], true
+}
+
+// Err returns the error of the entry from origin i to destination j, or nil if the route was calculated.
+func (m Matrix) Err(i, j int) error {
+	return m.errorCode(m.index(i, j)).Err()
+}
+
+// Cell returns all values of the entry from origin i to destination j.
+func (m Matrix) Cell(i, j int) MatrixCell {
+	cell := MatrixCell{Origin: i, Destination: j, Err: m.Err(i, j)}
+	cell.TravelTime, _ = m.TravelTime(i, j)
+	cell.Distance, _ = m.Distance(i, j)
+	return cell
+}
+
+// Row returns the entries from origin i to all destinations.
+func (m Matrix) Row(i int) []MatrixCell {
+	row := make([]MatrixCell, 0, m.NumDestinations())
+	for j := 0; j < m.NumDestinations(); j++ {
+		row = append(row, m.Cell(i, j))
+	}
+	return row
+}
+
+// Column returns the entries from all origins to destination j.
+func (m Matrix) Column(j int) []MatrixCell {
+	column := make([]MatrixCell, 0, m.NumOrigins())
+	for i := 0; i < m.NumOrigins(); i++ {
+		column = append(column, m.Cell(i, j))
+	}
+	return column
+}
+
+func (m Matrix) index(i, j int) int {
+	if i < 0 || i >= m.NumOrigins() || j < 0 || j >= m.NumDestinations() {
+		panic(fmt.Sprintf("matrix index (%d, %d) out of range [%d, %d]", i, j, m.NumOrigins(), m.NumDestinations()))
+	}
+	if m.transposed {
+		i, j = j, i
+	}
+	return i*m.response.NumDestinations + j
+}
+
+func (m Matrix) errorCode(k int) ErrorCode {
+	// Error codes are omitted from the response if no errors occurred.
+	if k >= len(m.response.ErrorCodes) {
+		return ErrorCodeSuccess
+	}
+	return m.response.ErrorCodes[k]
+}
@@ -0,0 +1,99 @@
+package routingv8_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestMatrix(t *testing.T) {
+	t.Parallel()
+	m := routingv8.NewMatrix(&routingv8.MatrixResponse{
+		NumOrigins:      2,
+		NumDestinations: 3,
+		TravelTimes:     []int32{0, 10, 20, 30, 40, 50},
+		Distances:       []int32{0, 100, 200, 300, 400, 500},
+		ErrorCodes: routingv8.ErrorCodes{
+			routingv8.ErrorCodeSuccess,
+			routingv8.ErrorCodeSuccess,
+			routingv8.ErrorCodeDisconnected,
+			routingv8.ErrorCodeSuccess,
+			routingv8.ErrorCodeMatchingFailed,
+			routingv8.ErrorCodeSuccess,
+		},
+	})
+	assert.Equal(t, 2, m.NumOrigins())
+	assert.Equal(t, 3, m.NumDestinations())
+
+	travelTime, ok := m.TravelTime(1, 2)
+	assert.Check(t, ok)
+	assert.Equal(t, 50*time.Second, travelTime)
+	distance, ok := m.Distance(1, 0)
+	assert.Check(t, ok)
+	assert.Equal(t, int32(300), distance)
+
+	_, ok = m.TravelTime(0, 2)
+	assert.Check(t, !ok)
+	assert.Check(t, errors.Is(m.Err(0, 2), routingv8.ErrDisconnected))
+	assert.Check(t, errors.Is(m.Err(1, 1), routingv8.ErrMatchingFailed))
+	assert.NilError(t, m.Err(0, 1))
+
+	assert.DeepEqual(t, []routingv8.MatrixCell{
+		{Origin: 0, Destination: 1, TravelTime: 10 * time.Second, Distance: 100},
+		{Origin: 1, Destination: 1, Err: routingv8.ErrMatchingFailed},
+	}, m.Column(1), cmpErrors())
+
+	transposed := m.Transpose()
+	assert.Equal(t, 3, transposed.NumOrigins())
+	assert.Equal(t, 2, transposed.NumDestinations())
+	assert.DeepEqual(t, []routingv8.MatrixCell{
+		{Origin: 2, Destination: 0, Err: routingv8.ErrDisconnected},
+		{Origin: 2, Destination: 1, TravelTime: 50 * time.Second, Distance: 500},
+	}, transposed.Row(2), cmpErrors())
+	assert.DeepEqual(t, m.Row(0), transposed.Transpose().Row(0), cmpErrors())
+}
+
+func TestMatrix_NotRequested(t *testing.T) {
+	t.Parallel()
+	// One-to-many matrix with only distances requested and no errors.
+	m := routingv8.NewMatrix(&routingv8.MatrixResponse{
+		NumOrigins:      1,
+		NumDestinations: 2,
+		Distances:       []int32{100, 200},
+	})
+	_, ok := m.TravelTime(0, 1)
+	assert.Check(t, !ok)
+	distance, ok := m.Distance(0, 1)
+	assert.Check(t, ok)
+	assert.Equal(t, int32(200), distance)
+	assert.NilError(t, m.Err(0, 1))
+}
+
+func TestMatrix_OutOfRange(t *testing.T) {
+	t.Parallel()
+	m := routingv8.NewMatrix(&routingv8.MatrixResponse{NumOrigins: 1, NumDestinations: 2})
+	defer func() {
+		assert.Check(t, recover() != nil)
+	}()
+	m.Err(1, 0)
+}
+
+func TestErrorCode_Err(t *testing.T) {
+	t.Parallel()
+	assert.NilError(t, routingv8.ErrorCode(routingv8.ErrorCodeSuccess).Err())
+	assert.Check(t, errors.Is(
+		routingv8.ErrorCode(routingv8.ErrorCodeParameterViolation).Err(),
+		routingv8.ErrParameterViolation,
+	))
+	assert.Check(t, errors.Is(routingv8.ErrorCode(42).Err(), routingv8.ErrUnknown))
+}
+
+func cmpErrors() cmp.Option {
+	return cmp.Comparer(func(a, b error) bool {
+		return errors.Is(a, b) && errors.Is(b, a)
+	})
+}
@@ -0,0 +1,224 @@
+package routingv8
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMultiStopMaxVia      = 50
+	defaultMultiStopConcurrency = 4
+)
+
+type RoutesMultiStopRequest struct {
+	// Request is the template of the request for each chunk of stops. Origin, Destination, Via and ViaWaypoints
+	// are set from the stops of the chunk, all other parameters are passed on as is, except Alternatives and
+	// ArriveAt which are not supported. A DepartureTime other than DepartureTimeAny is handled as DepartAt.
+	Request *RoutesRequest
+	// Stops of the route in order, including the origin and destination. At least 2 stops are required.
+	// The origin, destination and stops where the route is split into chunks are the origin and destination of a
+	// chunk request, and can only set Location and StopDuration.
+	Stops []Waypoint
+	// MaxVia is the maximum number of via waypoints in a single request. Defaults to 50.
+	MaxVia int
+	// Concurrency is the maximum number of chunks calculated at the same time. Defaults to 4.
+	// Chunks are calculated sequentially if the departure time of Request is set.
+	Concurrency int
+}
+
+// RoutesMultiStop calculates a route along more stops than allowed in a single request, by splitting the stops into
+// chunks and calculating them concurrently. The last stop of each chunk is the first stop of the next chunk.
+// If the departure time of Request is set, the chunks are instead calculated one after the other, each departing
+// at the arrival at the previous chunk plus the stop duration in between, so that traffic dependent durations are
+// accurate.
+//
+// The routes of the chunks are merged into a single route, as if it had been calculated in a single request:
+// sections are continuous, Place.Waypoint indexes into Stops, and the departure and arrival times of each chunk
+// are shifted to follow the arrival at the previous chunk, including the stop duration of the stop in between.
+// The merged route has no ID or Handle, as it does not correspond to a single route of the API.
+func (s *RoutingService) RoutesMultiStop(
+	ctx context.Context,
+	req *RoutesMultiStopRequest,
+) (_ *RoutesResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("routes multi stop: %w", err)
+		}
+	}()
+	if req.Request == nil {
+		return nil, errors.New("InvalidArgument, request must be provided")
+	}
+	if len(req.Stops) < 2 {
+		return nil, errors.New("InvalidArgument, at least 2 stops must be provided")
+	}
+	if req.Request.Alternatives > 0 {
+		return nil, errors.New("InvalidArgument, alternatives are not supported")
+	}
+	if !req.Request.ArriveAt.IsZero() {
+		return nil, errors.New("InvalidArgument, arrival time is not supported")
+	}
+	template := *req.Request
+	if template.DepartureTime != "" && template.DepartureTime != DepartureTimeAny {
+		if !template.DepartAt.IsZero() {
+			return nil, errors.New("InvalidArgument, only one of DepartureTime and DepartAt can be set")
+		}
+		departAt, err := time.Parse(time.RFC3339, template.DepartureTime)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"InvalidArgument, departure time %q must be in RFC 3339 or %q", template.DepartureTime, DepartureTimeAny,
+			)
+		}
+		// Chain the departures of the chunks, as for DepartAt.
+		template.DepartureTime = ""
+		template.DepartAt = departAt
+	}
+	maxVia := req.MaxVia
+	if maxVia <= 0 {
+		maxVia = defaultMultiStopMaxVia
+	}
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultMultiStopConcurrency
+	}
+	var chunks [][2]int
+	for start := 0; start < len(req.Stops)-1; {
+		end := minInt(start+maxVia+1, len(req.Stops)-1)
+		chunks = append(chunks, [2]int{start, end})
+		start = end
+	}
+	for k, chunk := range chunks {
+		for _, i := range chunk {
+			if req.Stops[i].hasOptions() {
+				return nil, fmt.Errorf(
+					"InvalidArgument, stop %d is an endpoint of chunk %d, and can only set Location and StopDuration",
+					i,
+					k,
+				)
+			}
+		}
+	}
+	results := make([]*RoutesResponse, len(chunks))
+	errs := make([]error, len(chunks))
+	calculate := func(k int, departAt time.Time) {
+		start, end := chunks[k][0], chunks[k][1]
+		chunkReq := template
+		chunkReq.Origin = req.Stops[start].Location
+		chunkReq.Destination = req.Stops[end].Location
+		chunkReq.Via = nil
+		chunkReq.ViaWaypoints = req.Stops[start+1 : end]
+		chunkReq.DepartAt = departAt
+		results[k], errs[k] = s.Routes(ctx, &chunkReq)
+	}
+	if departAt := template.DepartAt; !departAt.IsZero() {
+		// Traffic depends on the departure time, so each chunk departs at the arrival at the previous chunk plus
+		// the stop duration in between, and chunks are calculated sequentially.
+		for k := range chunks {
+			calculate(k, departAt)
+			if errs[k] != nil || len(results[k].Routes) == 0 || len(results[k].Routes[0].Sections) == 0 {
+				break
+			}
+			sections := results[k].Routes[0].Sections
+			if arrival := sections[len(sections)-1].Arrival.Time; !arrival.IsZero() {
+				departAt = arrival.Add(req.Stops[chunks[k][1]].StopDuration / time.Second * time.Second)
+			}
+		}
+	} else {
+		semaphore := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for k := range chunks {
+			k := k
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				select {
+				case semaphore <- struct{}{}:
+					defer func() { <-semaphore }()
+				case <-ctx.Done():
+					errs[k] = ctx.Err()
+					return
+				}
+				calculate(k, time.Time{})
+			}()
+		}
+		wg.Wait()
+	}
+	var route Route
+	for k, chunk := range chunks {
+		if errs[k] != nil {
+			return nil, fmt.Errorf("chunk %d with stops [%d, %d]: %w", k, chunk[0], chunk[1], errs[k])
+		}
+		if len(results[k].Routes) == 0 {
+			return nil, fmt.Errorf("chunk %d with stops [%d, %d]: no route found", k, chunk[0], chunk[1])
+		}
+		chunkRoute := results[k].Routes[0]
+		if len(chunkRoute.Sections) == 0 {
+			return nil, fmt.Errorf("chunk %d with stops [%d, %d]: route has no sections", k, chunk[0], chunk[1])
+		}
+		var shift time.Duration
+		if k > 0 && len(route.Sections) > 0 {
+			previous := &route.Sections[len(route.Sections)-1]
+			if stopDuration := req.Stops[chunk[0]].StopDuration / time.Second * time.Second; stopDuration > 0 {
+				previous.PostActions = append(previous.PostActions, PostAction{
+					Action:   PostActionTypeWait,
+					Duration: int32(stopDuration / time.Second),
+				})
+				shift += stopDuration
+			}
+			// Without both times the chunk can only be shifted by the stop duration.
+			if first := chunkRoute.Sections[0].Departure.Time; !first.IsZero() && !previous.Arrival.Time.IsZero() {
+				shift += previous.Arrival.Time.Sub(first)
+			}
+		}
+		for _, section := range chunkRoute.Sections {
+			section.Departure = section.Departure.stitched(chunk[0], shift)
+			section.Arrival = section.Arrival.stitched(chunk[0], shift)
+			route.Sections = append(route.Sections, section)
+		}
+		route.Notices = append(route.Notices, chunkRoute.Notices...)
+	}
+	resp := &RoutesResponse{Routes: []Route{route}}
+	for _, result := range results {
+		resp.Notices = append(resp.Notices, result.Notices...)
+	}
+	return resp, nil
+}
+
+// hasOptions returns true if the waypoint sets options other than its location and stop duration, which a
+// RoutesRequest only supports for via waypoints.
+func (w *Waypoint) hasOptions() bool {
+	return w.PassThrough || w.Course != nil || w.SideOfStreetHint != nil ||
+		w.MatchSideOfStreet != MatchSideOfStreetUnspecified || w.Radius != 0 || w.NameHint != ""
+}
+
+// stitched returns the departure or arrival of a chunk of a multi-stop route, with the waypoint index offset by
+// the index of the first stop of the chunk and the time shifted.
+func (v VehicleDeparture) stitched(waypointOffset int, shift time.Duration) VehicleDeparture {
+	if v.Place.Waypoint != nil {
+		waypoint := *v.Place.Waypoint + waypointOffset
+		v.Place.Waypoint = &waypoint
+	}
+	if !v.Time.IsZero() {
+		v.Time = v.Time.Add(shift)
+	}
+	return v
+}
+
+// Points returns the points of the polylines of all sections of the route, in order. The first point of each
+// section after the first is left out when it is the same as the last point of the previous section.
+func (r *Route) Points() ([]GeoWaypoint, error) {
+	var points []GeoWaypoint
+	for i := range r.Sections {
+		sectionPoints, err := r.Sections[i].Polyline.Decode()
+		if err != nil {
+			return nil, fmt.Errorf("section %d: %v", i, err)
+		}
+		if len(points) > 0 && len(sectionPoints) > 0 && points[len(points)-1] == sectionPoints[0] {
+			sectionPoints = sectionPoints[1:]
+		}
+		points = append(points, sectionPoints...)
+	}
+	return points, nil
+}
@@ -0,0 +1,368 @@
+package routingv8_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+// MultiStopMock returns a route with one section of 100 seconds and 1000 meters per leg between the waypoints of
+// the request, departing at the requested departure time or 08:00.
+type MultiStopMock struct {
+	mu                 sync.Mutex
+	requestsQuery      []string
+	failOrigin         string
+	omitDepartureTimes bool
+}
+
+func (c *MultiStopMock) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.requestsQuery = append(c.requestsQuery, req.URL.RawQuery)
+	c.mu.Unlock()
+	query := req.URL.Query()
+	if query.Get("origin") == c.failOrigin {
+		return nil, errors.New("boom")
+	}
+	waypoints := append(append([]string{query.Get("origin")}, query["via"]...), query.Get("destination"))
+	parse := func(s string) routingv8.GeoWaypoint {
+		// Strip the options of via waypoints.
+		if i := strings.IndexAny(s, ";!"); i >= 0 {
+			s = s[:i]
+		}
+		latLng := strings.Split(s, ",")
+		lat, _ := strconv.ParseFloat(latLng[0], 64)
+		lng, _ := strconv.ParseFloat(latLng[1], 64)
+		return routingv8.GeoWaypoint{Lat: lat, Long: lng}
+	}
+	departure := time.Date(2021, 11, 1, 8, 0, 0, 0, time.UTC)
+	if departureTime := query.Get("departureTime"); departureTime != "" {
+		parsed, err := time.Parse(time.RFC3339, departureTime)
+		if err != nil {
+			return nil, err
+		}
+		departure = parsed
+	}
+	var route routingv8.Route
+	for i := 0; i+1 < len(waypoints); i++ {
+		from, to := parse(waypoints[i]), parse(waypoints[i+1])
+		polyline, err := routingv8.EncodePolyline(routingv8.PolylineHeader{Precision: 5}, []routingv8.GeoWaypoint{from, to})
+		if err != nil {
+			return nil, err
+		}
+		fromIndex, toIndex := i, i+1
+		departureTime := departure
+		if c.omitDepartureTimes {
+			departureTime = time.Time{}
+		}
+		route.Sections = append(route.Sections, routingv8.Section{
+			Type: "vehicle",
+			Departure: routingv8.VehicleDeparture{
+				Place: routingv8.Place{Type: "place", Location: from, Waypoint: &fromIndex},
+				Time:  departureTime,
+			},
+			Arrival: routingv8.VehicleDeparture{
+				Place: routingv8.Place{Type: "place", Location: to, Waypoint: &toIndex},
+				Time:  departure.Add(100 * time.Second),
+			},
+			Summary:  routingv8.Summary{Duration: 100, Length: 1000},
+			Polyline: polyline,
+		})
+		departure = departure.Add(100 * time.Second)
+	}
+	b, err := json.Marshal(routingv8.RoutesResponse{Routes: []routingv8.Route{route}})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(b)),
+		ContentLength: int64(len(b)),
+	}, nil
+}
+
+func multiStopStops(n int) []routingv8.Waypoint {
+	stops := make([]routingv8.Waypoint, 0, n)
+	for i := 0; i < n; i++ {
+		stops = append(stops, routingv8.Waypoint{Location: routingv8.GeoWaypoint{Lat: 57 + float64(i)/10, Long: 12}})
+	}
+	return stops
+}
+
+func TestRoutingService_RoutesMultiStop(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := MultiStopMock{}
+	routingClient := routingv8.NewClient(&client)
+	stops := multiStopStops(7)
+	stops[2].StopDuration = 5 * time.Minute
+	got, err := routingClient.Routing.RoutesMultiStop(ctx, &routingv8.RoutesMultiStopRequest{
+		Request: &routingv8.RoutesRequest{
+			TransportMode: routingv8.TransportModeTruck,
+			Return:        []routingv8.ReturnAttribute{routingv8.SummaryReturnAttribute, routingv8.PolylineReturnAttribute},
+		},
+		Stops:  stops,
+		MaxVia: 1,
+	})
+	assert.NilError(t, err)
+	sort.Strings(client.requestsQuery)
+	assert.DeepEqual(t, []string{
+		"destination=57.2%2C12&origin=57%2C12&return=summary%2Cpolyline&transportMode=truck&via=57.1%2C12",
+		"destination=57.4%2C12&origin=57.2%2C12&return=summary%2Cpolyline&transportMode=truck&via=57.3%2C12",
+		"destination=57.6%2C12&origin=57.4%2C12&return=summary%2Cpolyline&transportMode=truck&via=57.5%2C12",
+	}, client.requestsQuery)
+	assert.Equal(t, 1, len(got.Routes))
+	route := got.Routes[0]
+	assert.Equal(t, 6, len(route.Sections))
+	start := time.Date(2021, 11, 1, 8, 0, 0, 0, time.UTC)
+	expectedDepartures := []time.Time{
+		start,
+		start.Add(100 * time.Second),
+		// Stop duration of 5 minutes at the stop between the first and second chunk.
+		start.Add(500 * time.Second),
+		start.Add(600 * time.Second),
+		start.Add(700 * time.Second),
+		start.Add(800 * time.Second),
+	}
+	for i, section := range route.Sections {
+		assert.Equal(t, i, *section.Departure.Place.Waypoint)
+		assert.Equal(t, i+1, *section.Arrival.Place.Waypoint)
+		assert.Equal(t, stops[i].Location, section.Departure.Place.Location)
+		assert.Equal(t, expectedDepartures[i], section.Departure.Time)
+		assert.Equal(t, expectedDepartures[i].Add(100*time.Second), section.Arrival.Time)
+	}
+	assert.DeepEqual(t, []routingv8.PostAction{
+		{Action: routingv8.PostActionTypeWait, Duration: 300},
+	}, route.Sections[1].PostActions)
+	assert.Equal(t, routingv8.Summary{Duration: 600, Length: 6000}, route.Summary())
+	points, err := route.Points()
+	assert.NilError(t, err)
+	assert.Equal(t, 7, len(points))
+	for i, point := range points {
+		assert.Equal(t, stops[i].Location, point)
+	}
+}
+
+func TestRoutingService_RoutesMultiStop_DepartAt(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := MultiStopMock{}
+	routingClient := routingv8.NewClient(&client)
+	stops := multiStopStops(5)
+	stops[2].StopDuration = 5 * time.Minute
+	start := time.Date(2021, 11, 1, 10, 0, 0, 0, time.UTC)
+	got, err := routingClient.Routing.RoutesMultiStop(ctx, &routingv8.RoutesMultiStopRequest{
+		Request: &routingv8.RoutesRequest{TransportMode: routingv8.TransportModeTruck, DepartAt: start},
+		Stops:   stops,
+		MaxVia:  1,
+	})
+	assert.NilError(t, err)
+	// The second chunk departs at the arrival at the first chunk plus the stop duration.
+	assert.DeepEqual(t, []string{
+		"departureTime=2021-11-01T10%3A00%3A00Z&destination=57.2%2C12&origin=57%2C12" +
+			"&return=summary&transportMode=truck&via=57.1%2C12",
+		"departureTime=2021-11-01T10%3A08%3A20Z&destination=57.4%2C12&origin=57.2%2C12" +
+			"&return=summary&transportMode=truck&via=57.3%2C12",
+	}, client.requestsQuery)
+	route := got.Routes[0]
+	expectedDepartures := []time.Time{
+		start,
+		start.Add(100 * time.Second),
+		start.Add(500 * time.Second),
+		start.Add(600 * time.Second),
+	}
+	for i, section := range route.Sections {
+		assert.Equal(t, expectedDepartures[i], section.Departure.Time)
+		assert.Equal(t, expectedDepartures[i].Add(100*time.Second), section.Arrival.Time)
+	}
+}
+
+func TestRoutingService_RoutesMultiStop_DepartureTime(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := MultiStopMock{}
+	routingClient := routingv8.NewClient(&client)
+	stops := multiStopStops(5)
+	stops[2].StopDuration = 5 * time.Minute
+	_, err := routingClient.Routing.RoutesMultiStop(ctx, &routingv8.RoutesMultiStopRequest{
+		Request: &routingv8.RoutesRequest{
+			TransportMode: routingv8.TransportModeTruck,
+			DepartureTime: "2021-11-01T10:00:00+01:00",
+		},
+		Stops:  stops,
+		MaxVia: 1,
+	})
+	assert.NilError(t, err)
+	// The departure time is chained as DepartAt, keeping its UTC offset.
+	assert.DeepEqual(t, []string{
+		"departureTime=2021-11-01T10%3A00%3A00%2B01%3A00&destination=57.2%2C12&origin=57%2C12" +
+			"&return=summary&transportMode=truck&via=57.1%2C12",
+		"departureTime=2021-11-01T10%3A08%3A20%2B01%3A00&destination=57.4%2C12&origin=57.2%2C12" +
+			"&return=summary&transportMode=truck&via=57.3%2C12",
+	}, client.requestsQuery)
+}
+
+func TestRoutingService_RoutesMultiStop_WaypointOptions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := MultiStopMock{}
+	routingClient := routingv8.NewClient(&client)
+	stops := multiStopStops(7)
+	course := 90
+	stops[1].Course = &course
+	stops[2].SideOfStreetHint = &routingv8.GeoWaypoint{Lat: 57.2, Long: 12.01}
+	stops[2].MatchSideOfStreet = routingv8.MatchSideOfStreetAlways
+	stops[3].StopDuration = 5 * time.Minute
+	stops[4].Radius = 50
+	stops[5].NameHint = "Storgatan"
+	stops[5].StopDuration = time.Minute
+	got, err := routingClient.Routing.RoutesMultiStop(ctx, &routingv8.RoutesMultiStopRequest{
+		Request: &routingv8.RoutesRequest{TransportMode: routingv8.TransportModeTruck},
+		Stops:   stops,
+		MaxVia:  2,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, 6, len(got.Routes[0].Sections))
+	// The options of the stops between the origin and destination of each chunk are passed on.
+	var vias [][]string
+	for _, query := range client.requestsQuery {
+		values, err := url.ParseQuery(query)
+		assert.NilError(t, err)
+		vias = append(vias, values["via"])
+	}
+	sort.Slice(vias, func(i, j int) bool { return vias[i][0] < vias[j][0] })
+	assert.DeepEqual(t, [][]string{
+		{"57.1,12;course=90", "57.2,12;sideOfStreetHint=57.2,12.01;matchSideOfStreet=always"},
+		{"57.4,12;radius=50", "57.5,12;nameHint=Storgatan!stopDuration=60"},
+	}, vias)
+}
+
+func TestRoutingService_RoutesMultiStop_WithoutDepartureTimes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := MultiStopMock{omitDepartureTimes: true}
+	routingClient := routingv8.NewClient(&client)
+	stops := multiStopStops(5)
+	stops[2].StopDuration = 5 * time.Minute
+	got, err := routingClient.Routing.RoutesMultiStop(ctx, &routingv8.RoutesMultiStopRequest{
+		Request: &routingv8.RoutesRequest{TransportMode: routingv8.TransportModeTruck},
+		Stops:   stops,
+		MaxVia:  1,
+	})
+	assert.NilError(t, err)
+	// Without departure times, chunks are only shifted by the stop duration.
+	start := time.Date(2021, 11, 1, 8, 0, 0, 0, time.UTC)
+	expectedArrivals := []time.Time{
+		start.Add(100 * time.Second),
+		start.Add(200 * time.Second),
+		start.Add(400 * time.Second),
+		start.Add(500 * time.Second),
+	}
+	for i, section := range got.Routes[0].Sections {
+		assert.Assert(t, section.Departure.Time.IsZero())
+		assert.Equal(t, expectedArrivals[i], section.Arrival.Time)
+	}
+}
+
+func TestRoutingService_RoutesMultiStop_Error(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name    string
+		request *routingv8.RoutesMultiStopRequest
+		errStr  string
+	}{
+		{
+			name: "too few stops",
+			request: &routingv8.RoutesMultiStopRequest{
+				Request: &routingv8.RoutesRequest{TransportMode: routingv8.TransportModeCar},
+				Stops:   multiStopStops(1),
+			},
+			errStr: "at least 2 stops must be provided",
+		},
+		{
+			name: "with alternatives",
+			request: &routingv8.RoutesMultiStopRequest{
+				Request: &routingv8.RoutesRequest{TransportMode: routingv8.TransportModeCar, Alternatives: 1},
+				Stops:   multiStopStops(3),
+			},
+			errStr: "alternatives are not supported",
+		},
+		{
+			name: "invalid departure time",
+			request: &routingv8.RoutesMultiStopRequest{
+				Request: &routingv8.RoutesRequest{TransportMode: routingv8.TransportModeCar, DepartureTime: "now"},
+				Stops:   multiStopStops(3),
+			},
+			errStr: `departure time "now" must be in RFC 3339`,
+		},
+		{
+			name: "departure time and depart at",
+			request: &routingv8.RoutesMultiStopRequest{
+				Request: &routingv8.RoutesRequest{
+					TransportMode: routingv8.TransportModeCar,
+					DepartureTime: "2021-11-01T10:00:00Z",
+					DepartAt:      time.Date(2021, 11, 1, 10, 0, 0, 0, time.UTC),
+				},
+				Stops: multiStopStops(3),
+			},
+			errStr: "only one of DepartureTime and DepartAt can be set",
+		},
+		{
+			name: "options on chunk endpoint",
+			request: &routingv8.RoutesMultiStopRequest{
+				Request: &routingv8.RoutesRequest{TransportMode: routingv8.TransportModeCar},
+				Stops: func() []routingv8.Waypoint {
+					stops := multiStopStops(5)
+					stops[2].Radius = 50
+					return stops
+				}(),
+				MaxVia: 1,
+			},
+			errStr: "stop 2 is an endpoint of chunk 0, and can only set Location and StopDuration",
+		},
+		{
+			name: "options on destination",
+			request: &routingv8.RoutesMultiStopRequest{
+				Request: &routingv8.RoutesRequest{TransportMode: routingv8.TransportModeCar},
+				Stops: func() []routingv8.Waypoint {
+					stops := multiStopStops(3)
+					stops[2].NameHint = "Storgatan"
+					return stops
+				}(),
+			},
+			errStr: "stop 2 is an endpoint of chunk 0",
+		},
+		{
+			name: "failed chunk",
+			request: &routingv8.RoutesMultiStopRequest{
+				Request: &routingv8.RoutesRequest{TransportMode: routingv8.TransportModeCar},
+				Stops:   multiStopStops(5),
+				MaxVia:  1,
+			},
+			errStr: "chunk 1 with stops [2, 4]: boom",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := MultiStopMock{failOrigin: "57.2,12"}
+			routingClient := routingv8.NewClient(&client)
+			_, err := routingClient.Routing.RoutesMultiStop(ctx, tt.request)
+			assert.ErrorContains(t, err, tt.errStr)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package routingv8
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Notice is an issue related to a route calculation, a route or a section of a route.
+type Notice struct {
+	// Human-readable notice description.
+	Title string `json:"title"`
+	// Machine-readable notice code.
+	// See https://developer.here.com/documentation/routing-api/api-reference-swagger.html
+	// for possible values, and NoticeCode for a typed code.
+	Code string `json:"code"`
+	// Describes the impact a notice has on the resource to which the notice is attached.
+	Severity NoticeSeverity `json:"severity"`
+	// Additional details about the notice. Use DecodeDetails to decode them.
+	// See https://developer.here.com/documentation/routing-api/api-reference-swagger.html
+	// for possible values.
+	Details []json.RawMessage `json:"details"`
+}
+
+// RouteResponseNotice is a notice of a route calculation.
+type RouteResponseNotice = Notice
+
+// VehicleNotice is a notice of a section of a route.
+type VehicleNotice = Notice
+
+type NoticeSeverity string
+
+const (
+	// CriticalNoticeSeverity is used to indicate that the notice must not be ignored,
+	// even if the type of notice is not known to the user.
+	// Any associated resource (e.g., route section) must not be used without further evaluation.
+	CriticalNoticeSeverity NoticeSeverity = "critical"
+	// InfoNoticeSeverity is used to indicate that the notice is for informative purposes,
+	// but does not affect usability of the route.
+	InfoNoticeSeverity NoticeSeverity = "info"
+)
+
+// NoticeCode is the machine-readable code of a Notice.
+// New codes may be added by the API at any time, so unknown codes must be handled by their severity.
+type NoticeCode string
+
+const (
+	NoticeCodeNoRouteFound                                NoticeCode = "noRouteFound"
+	NoticeCodeCouldNotMatchOrigin                         NoticeCode = "couldNotMatchOrigin"
+	NoticeCodeCouldNotMatchDestination                    NoticeCode = "couldNotMatchDestination"
+	NoticeCodeUnknownError                                NoticeCode = "unknownError"
+	NoticeCodeViolatedAvoidControlledAccessHighway        NoticeCode = "violatedAvoidControlledAccessHighway"
+	NoticeCodeViolatedAvoidTollRoad                       NoticeCode = "violatedAvoidTollRoad"
+	NoticeCodeViolatedAvoidFerry                          NoticeCode = "violatedAvoidFerry"
+	NoticeCodeViolatedAvoidTunnel                         NoticeCode = "violatedAvoidTunnel"
+	NoticeCodeViolatedAvoidDirtRoad                       NoticeCode = "violatedAvoidDirtRoad"
+	NoticeCodeViolatedAvoidRailFerry                      NoticeCode = "violatedAvoidRailFerry"
+	NoticeCodeViolatedAvoidPark                           NoticeCode = "violatedAvoidPark"
+	NoticeCodeViolatedAvoidAreas                          NoticeCode = "violatedAvoidAreas"
+	NoticeCodeViolatedAvoidSegment                        NoticeCode = "violatedAvoidSegment"
+	NoticeCodeViolatedAvoidZone                           NoticeCode = "violatedAvoidZone"
+	NoticeCodeViolatedAvoidDifficultTurns                 NoticeCode = "violatedAvoidDifficultTurns"
+	NoticeCodeViolatedAvoidUTurns                         NoticeCode = "violatedAvoidUTurns"
+	NoticeCodeViolatedAvoidSeasonalClosure                NoticeCode = "violatedAvoidSeasonalClosure"
+	NoticeCodeViolatedExcludeCountries                    NoticeCode = "violatedExcludeCountries"
+	NoticeCodeViolatedVehicleRestriction                  NoticeCode = "violatedVehicleRestriction"
+	NoticeCodeViolatedBlockedRoad                         NoticeCode = "violatedBlockedRoad"
+	NoticeCodeViolatedStartDirection                      NoticeCode = "violatedStartDirection"
+	NoticeCodeViolatedCarpool                             NoticeCode = "violatedCarpool"
+	NoticeCodeViolatedTurnRestriction                     NoticeCode = "violatedTurnRestriction"
+	NoticeCodeViolatedEmergencyGate                       NoticeCode = "violatedEmergencyGate"
+	NoticeCodeViolatedZoneRestriction                     NoticeCode = "violatedZoneRestriction"
+	NoticeCodeSeasonalClosure                             NoticeCode = "seasonalClosure"
+	NoticeCodeTollTransponder                             NoticeCode = "tollTransponder"
+	NoticeCodeTollsDataUnavailable                        NoticeCode = "tollsDataUnavailable"
+	NoticeCodeTollsDataTemporarilyUnavailable             NoticeCode = "tollsDataTemporarilyUnavailable"
+	NoticeCodeChargingStopNotNeeded                       NoticeCode = "chargingStopNotNeeded"
+	NoticeCodeTargetChargeBelowMinChargeAtChargingStation NoticeCode = "targetChargeBelowMinChargeAtChargingStation"
+	NoticeCodeTravelTimeExceedsDriverWorkHours            NoticeCode = "travelTimeExceedsDriverWorkHours"
+)
+
+// NoticeDetail is a decoded detail of a Notice.
+// Which fields are set depends on the Type, unknown types only have Type and Cause set.
+type NoticeDetail struct {
+	// Type of the detail, e.g. NoticeDetailTypeRestriction.
+	Type NoticeDetailType `json:"type"`
+	// Cause is a human-readable description of the detail.
+	Cause string `json:"cause"`
+	// MaxGrossWeight in kg allowed by a restriction.
+	MaxGrossWeight int `json:"maxGrossWeight"`
+	// MaxWeightPerAxle in kg allowed by a restriction.
+	MaxWeightPerAxle int `json:"maxWeightPerAxle"`
+	// MaxHeight in cm allowed by a restriction.
+	MaxHeight int `json:"maxHeight"`
+	// MaxWidth in cm allowed by a restriction.
+	MaxWidth int `json:"maxWidth"`
+	// MaxLength in cm allowed by a restriction.
+	MaxLength int `json:"maxLength"`
+	// TunnelCategory allowed by a restriction, e.g. "C".
+	TunnelCategory string `json:"tunnelCategory"`
+	// ForbiddenHazardousGoods of a restriction, e.g. "explosive".
+	ForbiddenHazardousGoods []string `json:"forbiddenHazardousGoods"`
+	// TimeDependent is true if a restriction only applies at certain times.
+	TimeDependent bool `json:"timeDependent"`
+	// RestrictedTimes of a time dependent restriction, e.g. "++(h22){h8}".
+	RestrictedTimes string `json:"restrictedTimes"`
+}
+
+// NoticeDetailType is the type of a NoticeDetail.
+type NoticeDetailType string
+
+const (
+	// NoticeDetailTypeRestriction is used for details of vehicle restrictions.
+	NoticeDetailTypeRestriction NoticeDetailType = "restriction"
+)
+
+// DecodeDetails decodes the details of the notice.
+func (n *Notice) DecodeDetails() ([]NoticeDetail, error) {
+	details := make([]NoticeDetail, 0, len(n.Details))
+	for i, raw := range n.Details {
+		var detail NoticeDetail
+		if err := json.Unmarshal(raw, &detail); err != nil {
+			return nil, fmt.Errorf("decode notice %s detail %d: %v", n.Code, i, err)
+		}
+		details = append(details, detail)
+	}
+	return details, nil
+}
+
+// NoticeCode returns the Code of the notice as a NoticeCode.
+func (n *Notice) NoticeCode() NoticeCode {
+	return NoticeCode(n.Code)
+}
+
+// IsCritical returns true if the notice has CriticalNoticeSeverity.
+func (n *Notice) IsCritical() bool {
+	return n.Severity == CriticalNoticeSeverity
+}
+
+// CriticalNotices returns all critical notices of the response, its routes and their sections.
+func (r *RoutesResponse) CriticalNotices() []Notice {
+	var notices []Notice
+	appendCritical := func(ns []Notice) {
+		for i := range ns {
+			if ns[i].IsCritical() {
+				notices = append(notices, ns[i])
+			}
+		}
+	}
+	appendCritical(r.Notices)
+	for i := range r.Routes {
+		appendCritical(r.Routes[i].Notices)
+		for j := range r.Routes[i].Sections {
+			appendCritical(r.Routes[i].Sections[j].Notices)
+		}
+	}
+	return notices
+}
+
+// A CriticalNoticeError reports the critical notices of a routes response.
+// It is only returned when Client.FailOnCriticalNotices is set.
+type CriticalNoticeError struct {
+	// Notices with CriticalNoticeSeverity.
+	Notices []Notice
+}
+
+func (e *CriticalNoticeError) Error() string {
+	notices := make([]string, 0, len(e.Notices))
+	for _, notice := range e.Notices {
+		notices = append(notices, fmt.Sprintf("%s: %s", notice.Code, notice.Title))
+	}
+	return fmt.Sprintf("critical notices: %s", strings.Join(notices, "; "))
+}
+
+// checkCriticalNotices returns a *CriticalNoticeError if the client fails on critical notices and the response
+// has any.
+func (c *Client) checkCriticalNotices(resp *RoutesResponse) error {
+	if !c.FailOnCriticalNotices {
+		return nil
+	}
+	if notices := resp.CriticalNotices(); len(notices) > 0 {
+		return &CriticalNoticeError{Notices: notices}
+	}
+	return nil
+}
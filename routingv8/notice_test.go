@@ -0,0 +1,119 @@
+package routingv8_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestNotice_DecodeDetails(t *testing.T) {
+	t.Parallel()
+	notice := routingv8.Notice{
+		Title:    "Violated vehicle restriction.",
+		Code:     "violatedVehicleRestriction",
+		Severity: routingv8.CriticalNoticeSeverity,
+		Details: []json.RawMessage{
+			[]byte(`{"type":"restriction","cause":"Route violates vehicle restriction","maxHeight":380}`),
+			[]byte(`{"type":"restriction","maxGrossWeight":3500,"timeDependent":true,"restrictedTimes":"++(h22){h8}"}`),
+		},
+	}
+	assert.Equal(t, routingv8.NoticeCodeViolatedVehicleRestriction, notice.NoticeCode())
+	got, err := notice.DecodeDetails()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []routingv8.NoticeDetail{
+		{
+			Type:      routingv8.NoticeDetailTypeRestriction,
+			Cause:     "Route violates vehicle restriction",
+			MaxHeight: 380,
+		},
+		{
+			Type:            routingv8.NoticeDetailTypeRestriction,
+			MaxGrossWeight:  3500,
+			TimeDependent:   true,
+			RestrictedTimes: "++(h22){h8}",
+		},
+	}, got)
+	notice.Details = append(notice.Details, []byte(`{"type":1}`))
+	_, err = notice.DecodeDetails()
+	assert.ErrorContains(t, err, "decode notice violatedVehicleRestriction detail 2")
+}
+
+func TestRoutingService_Routes_FailOnCriticalNotices(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	critical := routingv8.Notice{
+		Title:    "Violated vehicle restriction.",
+		Code:     "violatedVehicleRestriction",
+		Severity: routingv8.CriticalNoticeSeverity,
+	}
+	info := routingv8.Notice{
+		Title:    "Toll transponder required.",
+		Code:     "tollTransponder",
+		Severity: routingv8.InfoNoticeSeverity,
+	}
+	request := &routingv8.RoutesRequest{
+		Origin:        routingv8.GeoWaypoint{Lat: 57.707752, Long: 11.949767},
+		Destination:   routingv8.GeoWaypoint{Lat: 59.337492, Long: 18.063672},
+		TransportMode: routingv8.TransportModeTruck,
+	}
+	for _, tt := range []struct {
+		name                  string
+		failOnCriticalNotices bool
+		response              routingv8.RoutesResponse
+		expectedNotices       []routingv8.Notice
+	}{
+		{
+			name:                  "critical section notice",
+			failOnCriticalNotices: true,
+			response: routingv8.RoutesResponse{
+				Routes: []routingv8.Route{
+					{ID: "1", Sections: []routingv8.Section{{ID: "2", Notices: []routingv8.Notice{info, critical}}}},
+				},
+			},
+			expectedNotices: []routingv8.Notice{critical},
+		},
+		{
+			name:                  "critical response notice",
+			failOnCriticalNotices: true,
+			response: routingv8.RoutesResponse{
+				Notices: []routingv8.Notice{{Code: "noRouteFound", Severity: "critical"}},
+			},
+			expectedNotices: []routingv8.Notice{{Code: "noRouteFound", Severity: "critical"}},
+		},
+		{
+			name:                  "info notice",
+			failOnCriticalNotices: true,
+			response: routingv8.RoutesResponse{
+				Routes: []routingv8.Route{{ID: "1", Notices: []routingv8.Notice{info}}},
+			},
+		},
+		{
+			name: "critical notice without fail on critical notices",
+			response: routingv8.RoutesResponse{
+				Routes: []routingv8.Route{{ID: "1", Notices: []routingv8.Notice{critical}}},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := RoutesMock{responseBody: tt.response, responseStatus: http.StatusOK}
+			routingClient := routingv8.NewClient(&client)
+			routingClient.FailOnCriticalNotices = tt.failOnCriticalNotices
+			got, err := routingClient.Routing.Routes(ctx, request)
+			assert.DeepEqual(t, &tt.response, got)
+			if tt.expectedNotices == nil {
+				assert.NilError(t, err)
+				return
+			}
+			var criticalNoticeError *routingv8.CriticalNoticeError
+			assert.Assert(t, errors.As(err, &criticalNoticeError))
+			assert.DeepEqual(t, tt.expectedNotices, criticalNoticeError.Notices)
+		})
+	}
+}
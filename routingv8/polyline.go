@@ -0,0 +1,247 @@
+package routingv8
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// polylineFormatVersion is the only Flexible Polyline format version currently defined.
+const polylineFormatVersion = 1
+
+const polylineEncodingTable = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+var polylineDecodingTable = func() [256]int8 {
+	var table [256]int8
+	for i := range table {
+		table[i] = -1
+	}
+	for i := 0; i < len(polylineEncodingTable); i++ {
+		table[polylineEncodingTable[i]] = int8(i)
+	}
+	return table
+}()
+
+// ThirdDimension is the type of the optional third dimension of a Flexible Polyline.
+type ThirdDimension int
+
+const (
+	ThirdDimensionAbsent    ThirdDimension = 0
+	ThirdDimensionLevel     ThirdDimension = 1
+	ThirdDimensionAltitude  ThirdDimension = 2
+	ThirdDimensionElevation ThirdDimension = 3
+	// Values 4 and 5 are reserved by the Flexible Polyline specification.
+	ThirdDimensionCustom1 ThirdDimension = 6
+	ThirdDimensionCustom2 ThirdDimension = 7
+)
+
+func (t *ThirdDimension) String() string {
+	switch *t {
+	case ThirdDimensionAbsent:
+		return "absent"
+	case ThirdDimensionLevel:
+		return "level"
+	case ThirdDimensionAltitude:
+		return "altitude"
+	case ThirdDimensionElevation:
+		return "elevation"
+	case ThirdDimensionCustom1:
+		return "custom1"
+	case ThirdDimensionCustom2:
+		return "custom2"
+	default:
+		return invalid
+	}
+}
+
+// PolylineHeader describes how the coordinates of a Flexible Polyline are encoded.
+type PolylineHeader struct {
+	// Precision is the number of decimal digits kept for latitude and longitude, between 0 and 15.
+	Precision int
+	// ThirdDimension is the type of the optional third dimension.
+	ThirdDimension ThirdDimension
+	// ThirdDimensionPrecision is the number of decimal digits kept for the third dimension, between 0 and 15.
+	ThirdDimensionPrecision int
+}
+
+func (h *PolylineHeader) validate() error {
+	if h.Precision < 0 || h.Precision > 15 {
+		return fmt.Errorf("invalid precision %d", h.Precision)
+	}
+	if h.ThirdDimensionPrecision < 0 || h.ThirdDimensionPrecision > 15 {
+		return fmt.Errorf("invalid third dimension precision %d", h.ThirdDimensionPrecision)
+	}
+	if h.ThirdDimension.String() == invalid {
+		return fmt.Errorf("invalid third dimension %d", h.ThirdDimension)
+	}
+	return nil
+}
+
+// Header returns the header of the polyline.
+func (p Polyline) Header() (PolylineHeader, error) {
+	header, _, err := p.decodeHeader()
+	return header, err
+}
+
+// Decode returns the points of the polyline.
+// If the polyline has a third dimension, its value is stored in the Elevation field of each point regardless of
+// the dimension type, see Header for which type it is.
+// The points are in the order of the polyline, so Span.Offset can be used as an index into the result.
+func (p Polyline) Decode() ([]GeoWaypoint, error) {
+	if p == "" {
+		return nil, nil
+	}
+	header, i, err := p.decodeHeader()
+	if err != nil {
+		return nil, err
+	}
+	s := string(p)
+	multiplier := math.Pow10(header.Precision)
+	multiplierZ := math.Pow10(header.ThirdDimensionPrecision)
+	hasZ := header.ThirdDimension != ThirdDimensionAbsent
+	var lat, lng, z int64
+	var points []GeoWaypoint
+	for i < len(s) {
+		var v uint64
+		if v, i, err = decodeUnsignedValue(s, i); err != nil {
+			return nil, fmt.Errorf("decode polyline: %w", err)
+		}
+		lat += toSignedValue(v)
+		if v, i, err = decodeUnsignedValue(s, i); err != nil {
+			return nil, fmt.Errorf("decode polyline: %w", err)
+		}
+		lng += toSignedValue(v)
+		point := GeoWaypoint{
+			Lat:  float64(lat) / multiplier,
+			Long: float64(lng) / multiplier,
+		}
+		if hasZ {
+			if v, i, err = decodeUnsignedValue(s, i); err != nil {
+				return nil, fmt.Errorf("decode polyline: %w", err)
+			}
+			z += toSignedValue(v)
+			point.Elevation = float64(z) / multiplierZ
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+func (p Polyline) decodeHeader() (PolylineHeader, int, error) {
+	s := string(p)
+	version, i, err := decodeUnsignedValue(s, 0)
+	if err != nil {
+		return PolylineHeader{}, 0, fmt.Errorf("decode polyline header: %w", err)
+	}
+	if version != polylineFormatVersion {
+		return PolylineHeader{}, 0, fmt.Errorf("decode polyline header: unsupported format version %d", version)
+	}
+	content, i, err := decodeUnsignedValue(s, i)
+	if err != nil {
+		return PolylineHeader{}, 0, fmt.Errorf("decode polyline header: %w", err)
+	}
+	header := PolylineHeader{
+		Precision:               int(content & 0x0f),
+		ThirdDimension:          ThirdDimension((content >> 4) & 0x07),
+		ThirdDimensionPrecision: int((content >> 7) & 0x0f),
+	}
+	if err := header.validate(); err != nil {
+		return PolylineHeader{}, 0, fmt.Errorf("decode polyline header: %w", err)
+	}
+	return header, i, nil
+}
+
+// EncodePolyline encodes the points as a Flexible Polyline using the given header.
+// If the header has a third dimension, it is read from the Elevation field of each point.
+func EncodePolyline(header PolylineHeader, points []GeoWaypoint) (Polyline, error) {
+	if err := header.validate(); err != nil {
+		return "", fmt.Errorf("encode polyline: %w", err)
+	}
+	content := uint64(header.ThirdDimensionPrecision)<<7 | uint64(header.ThirdDimension)<<4 | uint64(header.Precision)
+	b := appendUnsignedValue(nil, polylineFormatVersion)
+	b = appendUnsignedValue(b, content)
+	multiplier := math.Pow10(header.Precision)
+	multiplierZ := math.Pow10(header.ThirdDimensionPrecision)
+	hasZ := header.ThirdDimension != ThirdDimensionAbsent
+	var lastLat, lastLng, lastZ int64
+	for _, point := range points {
+		lat := int64(math.Round(point.Lat * multiplier))
+		b = appendSignedValue(b, lat-lastLat)
+		lastLat = lat
+		lng := int64(math.Round(point.Long * multiplier))
+		b = appendSignedValue(b, lng-lastLng)
+		lastLng = lng
+		if hasZ {
+			z := int64(math.Round(point.Elevation * multiplierZ))
+			b = appendSignedValue(b, z-lastZ)
+			lastZ = z
+		}
+	}
+	return Polyline(b), nil
+}
+
+// SpanPoints returns the points of the section polyline covered by each span, in the same order as Spans.
+// A span covers the points from its offset up to and including the offset of the next span, or the last point
+// of the polyline for the last span.
+func (s *Section) SpanPoints() ([][]GeoWaypoint, error) {
+	points, err := s.Polyline.Decode()
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]GeoWaypoint, 0, len(s.Spans))
+	for i, span := range s.Spans {
+		end := len(points) - 1
+		if i+1 < len(s.Spans) {
+			end = s.Spans[i+1].Offset
+		}
+		if span.Offset < 0 || span.Offset > end || end >= len(points) {
+			return nil, fmt.Errorf("span %d: offset %d out of range of polyline with %d points", i, span.Offset, len(points))
+		}
+		result = append(result, points[span.Offset:end+1])
+	}
+	return result, nil
+}
+
+func decodeUnsignedValue(s string, i int) (uint64, int, error) {
+	var value uint64
+	var shift uint
+	for ; i < len(s); i++ {
+		v := polylineDecodingTable[s[i]]
+		if v < 0 {
+			return 0, 0, fmt.Errorf("invalid character %q at position %d", s[i], i)
+		}
+		if shift > 60 {
+			return 0, 0, fmt.Errorf("value overflow at position %d", i)
+		}
+		value |= uint64(v&0x1f) << shift
+		if v&0x20 == 0 {
+			return value, i + 1, nil
+		}
+		shift += 5
+	}
+	return 0, 0, errors.New("unexpected end of polyline")
+}
+
+func toSignedValue(v uint64) int64 {
+	value := int64(v)
+	if value&1 == 1 {
+		value = ^value
+	}
+	return value >> 1
+}
+
+func appendUnsignedValue(b []byte, v uint64) []byte {
+	for v > 0x1f {
+		b = append(b, polylineEncodingTable[(v&0x1f)|0x20])
+		v >>= 5
+	}
+	return append(b, polylineEncodingTable[v])
+}
+
+func appendSignedValue(b []byte, v int64) []byte {
+	value := v << 1
+	if v < 0 {
+		value = ^value
+	}
+	return appendUnsignedValue(b, uint64(value))
+}
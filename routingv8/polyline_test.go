@@ -0,0 +1,133 @@
+package routingv8_test
+
+import (
+	"testing"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestPolyline_Decode(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name           string
+		polyline       routingv8.Polyline
+		expectedHeader routingv8.PolylineHeader
+		expected       []routingv8.GeoWaypoint
+	}{
+		{
+			name:           "2d",
+			polyline:       "BFoz5xJ67i1B1B7PzIhaxL7Y",
+			expectedHeader: routingv8.PolylineHeader{Precision: 5},
+			expected: []routingv8.GeoWaypoint{
+				{Lat: 50.10228, Long: 8.69821},
+				{Lat: 50.10201, Long: 8.69567},
+				{Lat: 50.10063, Long: 8.69150},
+				{Lat: 50.09878, Long: 8.68752},
+			},
+		},
+		{
+			name:     "3d",
+			polyline: "BlBoz5xJ67i1BU1B7PUzIhaUxL7YU",
+			expectedHeader: routingv8.PolylineHeader{
+				Precision:      5,
+				ThirdDimension: routingv8.ThirdDimensionAltitude,
+			},
+			expected: []routingv8.GeoWaypoint{
+				{Lat: 50.10228, Long: 8.69821, Elevation: 10},
+				{Lat: 50.10201, Long: 8.69567, Elevation: 20},
+				{Lat: 50.10063, Long: 8.69150, Elevation: 30},
+				{Lat: 50.09878, Long: 8.68752, Elevation: 40},
+			},
+		},
+		{
+			name:           "empty",
+			polyline:       "",
+			expectedHeader: routingv8.PolylineHeader{},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.polyline.Decode()
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got)
+			if tt.polyline == "" {
+				return
+			}
+			header, err := tt.polyline.Header()
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expectedHeader, header)
+			encoded, err := routingv8.EncodePolyline(header, got)
+			assert.NilError(t, err)
+			assert.Equal(t, tt.polyline, encoded)
+		})
+	}
+}
+
+func TestPolyline_Decode_Error(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		polyline routingv8.Polyline
+		errStr   string
+	}{
+		{name: "unsupported version", polyline: "CFoz5xJ67i1B", errStr: "unsupported format version"},
+		{name: "reserved third dimension", polyline: "BlC", errStr: "invalid third dimension"},
+		{name: "invalid character", polyline: "BFoz5x*67i1B", errStr: "invalid character"},
+		{name: "truncated", polyline: "BFoz5xJ67i1B1B", errStr: "unexpected end"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := tt.polyline.Decode()
+			assert.ErrorContains(t, err, tt.errStr)
+		})
+	}
+}
+
+func TestEncodePolyline(t *testing.T) {
+	t.Parallel()
+	got, err := routingv8.EncodePolyline(
+		routingv8.PolylineHeader{Precision: 5},
+		[]routingv8.GeoWaypoint{
+			{Lat: 50.1022829, Long: 8.6982122},
+			{Lat: 50.1020076, Long: 8.6956695},
+			{Lat: 50.1006313, Long: 8.6914960},
+			{Lat: 50.0987800, Long: 8.6875156},
+		},
+	)
+	assert.NilError(t, err)
+	assert.Equal(t, routingv8.Polyline("BFoz5xJ67i1B1B7PzIhaxL7Y"), got)
+	_, err = routingv8.EncodePolyline(routingv8.PolylineHeader{Precision: 16}, nil)
+	assert.ErrorContains(t, err, "invalid precision")
+	_, err = routingv8.EncodePolyline(routingv8.PolylineHeader{ThirdDimension: 4}, nil)
+	assert.ErrorContains(t, err, "invalid third dimension")
+}
+
+func TestSection_SpanPoints(t *testing.T) {
+	t.Parallel()
+	section := routingv8.Section{
+		Polyline: "BFoz5xJ67i1B1B7PzIhaxL7Y",
+		Spans: []routingv8.Span{
+			{Offset: 0},
+			{Offset: 2},
+		},
+	}
+	got, err := section.SpanPoints()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, [][]routingv8.GeoWaypoint{
+		{
+			{Lat: 50.10228, Long: 8.69821},
+			{Lat: 50.10201, Long: 8.69567},
+			{Lat: 50.10063, Long: 8.69150},
+		},
+		{
+			{Lat: 50.10063, Long: 8.69150},
+			{Lat: 50.09878, Long: 8.68752},
+		},
+	}, got)
+	section.Spans = append(section.Spans, routingv8.Span{Offset: 4})
+	_, err = section.SpanPoints()
+	assert.ErrorContains(t, err, "out of range")
+}
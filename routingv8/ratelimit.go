@@ -0,0 +1,19 @@
+package routingv8
+
+import "go.einride.tech/here/internal/ratelimit"
+
+// RateLimiter limits the rate of requests to a service with a token bucket. It is safe for concurrent use, so a
+// single RateLimiter can be shared by all goroutines using a Client.
+//
+// The limiter adapts to the API: when a request is throttled with 429 Too Many Requests, the rate is halved, and
+// it recovers gradually towards the configured rate with each request that is not throttled.
+type RateLimiter = ratelimit.Limiter
+
+// RateLimiterStats is a snapshot of the state of a RateLimiter.
+type RateLimiterStats = ratelimit.Stats
+
+// NewRateLimiter returns a RateLimiter allowing qps requests per second on average, and bursts of up to burst
+// requests. If qps is not positive, requests are not limited.
+func NewRateLimiter(qps float64, burst int) *RateLimiter {
+	return ratelimit.New(qps, burst)
+}
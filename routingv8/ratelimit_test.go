@@ -0,0 +1,64 @@
+package routingv8_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.einride.tech/here/internal/httpmock"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestClient_RateLimiter(t *testing.T) {
+	t.Parallel()
+	t.Run("routes", func(t *testing.T) {
+		t.Parallel()
+		mock := &httpmock.Client{
+			StatusCodes: []int{429},
+			RetryAfter:  "0",
+			Body: func(*http.Request) string {
+				return `{"routes":[]}`
+			},
+		}
+		client := routingv8.NewClient(mock)
+		client.RetryPolicy = routingv8.RetryPolicy{MaxAttempts: 2}
+		client.Routing.RateLimiter = routingv8.NewRateLimiter(1000, 10)
+		client.Matrix.RateLimiter = routingv8.NewRateLimiter(1000, 10)
+		_, err := client.Routing.Routes(context.Background(), &routingv8.RoutesRequest{
+			Origin:        routingv8.GeoWaypoint{Lat: 1, Long: 2},
+			Destination:   routingv8.GeoWaypoint{Lat: 3, Long: 4},
+			TransportMode: routingv8.TransportModeCar,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, 2, len(mock.Bodies()))
+		// The rate is halved by the 429, and recovers by a twentieth with the successful retry.
+		stats := client.Routing.RateLimiter.Stats()
+		assert.Equal(t, 1, stats.Throttled)
+		assert.Equal(t, 550.0, stats.Rate)
+		assert.DeepEqual(t, routingv8.RateLimiterStats{Rate: 1000}, client.Matrix.RateLimiter.Stats())
+	})
+	t.Run("matrix result on another host", func(t *testing.T) {
+		t.Parallel()
+		mock := &httpmock.Client{
+			// The status is OK, and the download of the result is throttled.
+			StatusCodes: []int{200, 429},
+			RetryAfter:  "0",
+			Body: func(req *http.Request) string {
+				if strings.HasSuffix(req.URL.Path, "/status") {
+					return `{"matrixId": "id", "status": "completed",` +
+						` "resultUrl": "https://aws-eu-west-1.example.com/v8/matrix/id"}`
+				}
+				return `{"matrixId": "id", "matrix": {"numOrigins": 1, "numDestinations": 1}}`
+			},
+		}
+		client := routingv8.NewClient(mock)
+		client.RetryPolicy = routingv8.RetryPolicy{MaxAttempts: 2}
+		client.Matrix.RateLimiter = routingv8.NewRateLimiter(1000, 10)
+		_, err := client.Matrix.WaitForMatrix(context.Background(), "id")
+		assert.NilError(t, err)
+		assert.Equal(t, 3, len(mock.Bodies()))
+		assert.Equal(t, 1, client.Matrix.RateLimiter.Stats().Throttled)
+	})
+}
@@ -0,0 +1,89 @@
+package routingv8
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.einride.tech/here/internal/ratelimit"
+)
+
+// RecalculateRouteRequest contains the options to recalculate a route from its handle.
+type RecalculateRouteRequest struct {
+	// TransportMode must be the same as for the original route.
+	TransportMode TransportMode
+	Return        []ReturnAttribute
+	Spans         []SpanAttribute
+	// Origin is the current position along the route, e.g. of the vehicle driving it.
+	// If nil, the route is recalculated from its original origin.
+	Origin *GeoWaypoint
+	// DepartAt is the time of departure, to recalculate the route with the traffic at that time.
+	// If zero, the current time is used.
+	DepartAt time.Time
+	// Lang is the language of instructions, as a BCP 47 language tag, e.g. "en-US".
+	Lang string
+	// Truck configuration, encoded as vehicle parameters. Should match the original route.
+	Truck *Truck
+}
+
+// RecalculateRoute returns the route of a route handle, recalculated with current traffic.
+// The path of the route is kept, only durations and other traffic dependent attributes are updated.
+// Route handles are returned when requested with RouteHandleReturnAttribute.
+// See https://developer.here.com/documentation/routing-api/dev_guide/topics/use-cases/route-handle.html
+// for details about route handles.
+func (s *RoutingService) RecalculateRoute(
+	ctx context.Context,
+	handle string,
+	req *RecalculateRouteRequest,
+) (_ *RoutesResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("recalculate route: %w", err)
+		}
+	}()
+	if handle == "" {
+		return nil, errors.New("InvalidArgument, route handle must be provided")
+	}
+	tm := req.TransportMode.String()
+	if tm == invalid || tm == unspecified {
+		return nil, fmt.Errorf("invalid transportmode")
+	}
+	u, err := s.URL.Parse("routes/" + url.PathEscape(handle))
+	if err != nil {
+		return nil, err
+	}
+	values := make(url.Values)
+	values.Add("transportMode", tm)
+	if err := addReturnQueryValues(values, req.Return, req.Spans); err != nil {
+		return nil, err
+	}
+	if req.Origin != nil {
+		values.Add("origin", fmt.Sprintf("%v,%v", req.Origin.Lat, req.Origin.Long))
+	}
+	if !req.DepartAt.IsZero() {
+		values.Add("departureTime", formatTime(req.DepartAt))
+	}
+	if req.Lang != "" {
+		values.Add("lang", req.Lang)
+	}
+	if req.Truck != nil {
+		if err := req.Truck.addQueryValues(values); err != nil {
+			return nil, err
+		}
+	}
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodGet, values.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp RoutesResponse
+	if err := s.Client.Do(r, &resp); err != nil {
+		return nil, err
+	}
+	if err := s.Client.checkCriticalNotices(&resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,94 @@
+package routingv8_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestRoutingService_RecalculateRoute(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	exp := routingv8.RoutesResponse{
+		Routes: []routingv8.Route{
+			{
+				ID:     "1",
+				Handle: "AGQBAAAAHgAAAJ4ROz",
+				Sections: []routingv8.Section{
+					{ID: "2", Type: "vehicle", Summary: routingv8.Summary{Duration: 5400, Length: 120000}},
+				},
+			},
+		},
+	}
+	client := RoutesMock{responseBody: exp, responseStatus: http.StatusOK}
+	routingClient := routingv8.NewClient(&client)
+	got, err := routingClient.Routing.RecalculateRoute(ctx, "AGQBAAAAHgAAAJ4ROz/+", &routingv8.RecalculateRouteRequest{
+		TransportMode: routingv8.TransportModeTruck,
+		Return: []routingv8.ReturnAttribute{
+			routingv8.SummaryReturnAttribute,
+			routingv8.RouteHandleReturnAttribute,
+		},
+		Origin:   &routingv8.GeoWaypoint{Lat: 57.707752, Long: 11.949767},
+		DepartAt: time.Date(2021, 11, 1, 8, 0, 0, 0, time.UTC),
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, &exp, got)
+	assert.Equal(t, "/v8/routes/AGQBAAAAHgAAAJ4ROz%2F+", client.requestPath)
+	assert.Equal(
+		t,
+		"departureTime=2021-11-01T08%3A00%3A00Z&origin=57.707752%2C11.949767"+
+			"&return=summary%2CrouteHandle&transportMode=truck",
+		client.requestRawQuery,
+	)
+}
+
+func TestRoutingService_RecalculateRoute_Error(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name    string
+		handle  string
+		request *routingv8.RecalculateRouteRequest
+		errStr  string
+	}{
+		{
+			name:    "without handle",
+			request: &routingv8.RecalculateRouteRequest{TransportMode: routingv8.TransportModeCar},
+			errStr:  "route handle must be provided",
+		},
+		{
+			name:    "without transport mode",
+			handle:  "AGQBAAAAHgAAAJ4ROz",
+			request: &routingv8.RecalculateRouteRequest{},
+			errStr:  "invalid transportmode",
+		},
+		{
+			name:   "with response error",
+			handle: "expired",
+			request: &routingv8.RecalculateRouteRequest{
+				TransportMode: routingv8.TransportModeCar,
+			},
+			errStr: "Status: 400",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := RoutesMock{
+				responseStatus: http.StatusBadRequest,
+				error: &routingv8.HereErrorResponse{
+					Title:  "Invalid route handle",
+					Status: http.StatusBadRequest,
+					Code:   "E605201",
+				},
+			}
+			routingClient := routingv8.NewClient(&client)
+			_, err := routingClient.Routing.RecalculateRoute(ctx, tt.handle, tt.request)
+			assert.ErrorContains(t, err, tt.errStr)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 const (
@@ -23,7 +24,11 @@ type CalculateMatrixBody struct {
 	// for guidance on the matrix limitations.
 	Destinations []*GeoWaypoint `json:"destinations"`
 	// DepartureTime of departure for all origins. Default to now.
+	// Mutually exclusive with DepartAt.
 	DepartureTime string `json:"departureTime,omitempty"`
+	// DepartAt is the time of departure for all origins, encoded as DepartureTime in RFC 3339 with the UTC offset
+	// of its location.
+	DepartAt time.Time `json:"-"`
 	// RegionDefinition of where the matrix should be calculated.
 	RegionDefinition RegionDefinition `json:"regionDefinition"`
 	// Profile to use for route calculation in the matrix.
@@ -36,6 +41,22 @@ type CalculateMatrixBody struct {
 	MatrixAttributes *MatrixAttributes `json:"matrixAttributes,omitempty"`
 	// Truck configuration
 	Truck *Truck `json:"truck,omitempty"`
+	// Avoid features, areas, segments and zones.
+	Avoid *Avoid `json:"avoid,omitempty"`
+	// Exclude countries.
+	Exclude *Exclude `json:"exclude,omitempty"`
+}
+
+func (b *CalculateMatrixBody) MarshalJSON() ([]byte, error) {
+	type body CalculateMatrixBody
+	v := body(*b)
+	if !v.DepartAt.IsZero() {
+		if v.DepartureTime != "" {
+			return nil, fmt.Errorf("only one of DepartureTime and DepartAt can be set")
+		}
+		v.DepartureTime = formatTime(v.DepartAt)
+	}
+	return json.Marshal(&v)
 }
 
 type CalculateMatrixRequest struct {
@@ -47,22 +68,53 @@ type CalculateMatrixRequest struct {
 }
 
 type RoutesRequest struct {
-	Origin        GeoWaypoint
-	Destination   GeoWaypoint
-	Via           []GeoWaypoint
+	Origin      GeoWaypoint
+	Destination GeoWaypoint
+	Via         []GeoWaypoint
+	// ViaWaypoints are via waypoints with options, such as stop durations.
+	// Mutually exclusive with Via.
+	ViaWaypoints  []Waypoint
 	TransportMode TransportMode
-	AvoidAreas    []AreaFeature
+	// AvoidAreas are the features to avoid, merged with the features of Avoid.
+	AvoidAreas []AreaFeature
+	// Avoid features, areas, segments and zones.
+	Avoid *Avoid
+	// Exclude countries.
+	Exclude *Exclude
 	// Which attributes to return in the response.
 	// If not specified defaults to SummaryReturnAttribute.
 	Return []ReturnAttribute
 	// The time of departure.
 	// If not specified the current time is used.
 	// To not take time into account use DepartureTimeAny.
+	// Mutually exclusive with DepartAt and ArriveAt.
 	DepartureTime string
+	// DepartAt is the time of departure, encoded in RFC 3339 with the UTC offset of its location.
+	// Mutually exclusive with DepartureTime and ArriveAt.
+	DepartAt time.Time
+	// ArriveAt is the desired time of arrival, encoded in RFC 3339 with the UTC offset of its location.
+	// The route is calculated backwards from the destination, so that it arrives at the given time.
+	// Mutually exclusive with DepartureTime and DepartAt.
+	ArriveAt time.Time
 	// Spans define which content attributes that are included in the response spans
 	Spans       []SpanAttribute
 	RoutingMode RoutingMode
 	TrafficMode TrafficMode
+	// Alternatives is the number of alternative routes to return in addition to the best route.
+	// The provided value must be between 0 and 6. Defaults to 0.
+	Alternatives int
+	// Lang is the BCP 47 language code of the returned instructions, e.g. "sv-SE".
+	// If not specified defaults to "en-US".
+	Lang string
+	// Currency in ISO 4217 format, e.g. "SEK", to convert toll prices to.
+	Currency string
+	// Tolls defines the vehicle properties used to calculate toll costs.
+	Tolls *TollOptions
+	// EV parameters of an electric vehicle, to calculate consumption and charging stops.
+	EV *EV
+	// Truck configuration, encoded as vehicle parameters.
+	// Uses the same units as in CalculateMatrixBody, so that routes and matrices respect the same restrictions.
+	Truck *Truck
 }
 
 type ReturnAttribute string
@@ -71,6 +123,21 @@ const (
 	PolylineReturnAttribute  ReturnAttribute = "polyline"
 	SummaryReturnAttribute   ReturnAttribute = "summary"
 	ElevationReturnAttribute ReturnAttribute = "elevation"
+	// TollsReturnAttribute returns the tolls to pay along each section.
+	TollsReturnAttribute ReturnAttribute = "tolls"
+	// ActionsReturnAttribute returns the maneuvers to take to complete each section.
+	ActionsReturnAttribute ReturnAttribute = "actions"
+	// InstructionsReturnAttribute includes human-readable instructions in the returned actions.
+	// Requires ActionsReturnAttribute or TurnByTurnActionsReturnAttribute.
+	InstructionsReturnAttribute ReturnAttribute = "instructions"
+	// TurnByTurnActionsReturnAttribute returns the actions for turn-by-turn guidance of each section.
+	TurnByTurnActionsReturnAttribute ReturnAttribute = "turnByTurnActions"
+	// RouteHandleReturnAttribute returns a handle of each route, used to recalculate it with RecalculateRoute.
+	RouteHandleReturnAttribute ReturnAttribute = "routeHandle"
+	// TravelSummaryReturnAttribute returns the travel summary of each section of a transit route.
+	TravelSummaryReturnAttribute ReturnAttribute = "travelSummary"
+	// IntermediateReturnAttribute returns the intermediate stops of each section of a transit route.
+	IntermediateReturnAttribute ReturnAttribute = "intermediate"
 )
 
 type GeoWaypoint struct {
@@ -85,6 +152,11 @@ type GeoWaypoint struct {
 // DepartureTimeAny enforces non time-aware routing.
 const DepartureTimeAny = "any"
 
+// formatTime formats t in RFC 3339 with the UTC offset of its location, which is the format expected by the API.
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
 type Profile int
 
 const (
@@ -443,6 +515,7 @@ func (t *TunnelCategory) MarshalJSON() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+// Truck configuration. Weights are in kilograms and dimensions in centimeters.
 type Truck struct {
 	ShippedHazardousGoods ShippedHazardousGoodsList `json:"shippedHazardousGoods"`
 	GrossWeight           int                       `json:"grossWeight"`
@@ -494,19 +567,31 @@ type SpanAttribute string
 // For available span attributes to implementation see:
 // https://www.here.com/docs/bundle/routing-api-v8-api-reference/page/index.html#tag/Routing/operation/calculateRoutes
 const (
-	SpanAttributeNames    SpanAttribute = "names"
-	SpanAttributeMaxSpeed SpanAttribute = "maxSpeed"
+	SpanAttributeNames            SpanAttribute = "names"
+	SpanAttributeMaxSpeed         SpanAttribute = "maxSpeed"
+	SpanAttributeSpeedLimit       SpanAttribute = "speedLimit"
+	SpanAttributeFunctionalClass  SpanAttribute = "functionalClass"
+	SpanAttributeSegmentID        SpanAttribute = "segmentId"
+	SpanAttributeCountryCode      SpanAttribute = "countryCode"
+	SpanAttributeStateCode        SpanAttribute = "stateCode"
+	SpanAttributeTruckAttributes  SpanAttribute = "truckAttributes"
+	SpanAttributeCarAttributes    SpanAttribute = "carAttributes"
+	SpanAttributeStreetAttributes SpanAttribute = "streetAttributes"
+	SpanAttributeDuration         SpanAttribute = "duration"
+	SpanAttributeLength           SpanAttribute = "length"
+	SpanAttributeDynamicSpeedInfo SpanAttribute = "dynamicSpeedInfo"
+	SpanAttributeTollSystems      SpanAttribute = "tollSystems"
+	SpanAttributeRouteNumbers     SpanAttribute = "routeNumbers"
+	SpanAttributeNotices          SpanAttribute = "notices"
 )
 
+// String returns the span attribute as is, so that attributes without a constant can be requested too.
+// Only the empty attribute is invalid.
 func (t *SpanAttribute) String() string {
-	switch *t {
-	case SpanAttributeNames:
-		return string(SpanAttributeNames)
-	case SpanAttributeMaxSpeed:
-		return string(SpanAttributeMaxSpeed)
-	default:
+	if *t == "" {
 		return invalid
 	}
+	return string(*t)
 }
 
 type TrafficMode int
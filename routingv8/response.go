@@ -3,6 +3,7 @@ package routingv8
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 type ErrorCodes []ErrorCode
@@ -50,12 +51,46 @@ type MatrixResponse struct {
 type CalculateMatrixResponse struct {
 	// MatrixID is unique identifier of the matrix
 	MatrixID string `json:"matrixId"`
+	// Status of the calculation. Only set when the matrix was requested in async mode.
+	Status MatrixStatus `json:"status,omitempty"`
+	// StatusURL to poll for the status of the calculation. Only set when the matrix was requested in async mode.
+	StatusURL string `json:"statusUrl,omitempty"`
 	// Matrix contains the calculated matrix data.
 	Matrix MatrixResponse `json:"matrix"`
 	// RegionDefinition to be used to calculate matrix.
 	RegionDefinition RegionDefinition `json:"regionDefinition"`
 }
 
+// MatrixStatus is the status of an async matrix calculation.
+type MatrixStatus string
+
+const (
+	// MatrixStatusAccepted is used when the calculation has been accepted but not yet started.
+	MatrixStatusAccepted MatrixStatus = "accepted"
+	// MatrixStatusInProgress is used when the calculation is running.
+	MatrixStatusInProgress MatrixStatus = "inProgress"
+	// MatrixStatusCompleted is used when the calculation has finished and the result can be downloaded.
+	MatrixStatusCompleted MatrixStatus = "completed"
+	// MatrixStatusFailed is used when the calculation has failed. See MatrixStatusResponse.Error for the cause.
+	MatrixStatusFailed MatrixStatus = "failed"
+	// MatrixStatusTimedOut is used when the calculation took too long and was aborted.
+	MatrixStatusTimedOut MatrixStatus = "timedOut"
+)
+
+// MatrixStatusResponse contains the status of an async matrix calculation.
+type MatrixStatusResponse struct {
+	// MatrixID is unique identifier of the matrix
+	MatrixID string `json:"matrixId"`
+	// Status of the calculation.
+	Status MatrixStatus `json:"status"`
+	// StatusURL to poll for the status of the calculation.
+	StatusURL string `json:"statusUrl,omitempty"`
+	// ResultURL to download the calculated matrix from. Only set when the calculation is completed.
+	ResultURL string `json:"resultUrl,omitempty"`
+	// Error describing why the calculation failed. Only set when the calculation has failed.
+	Error *HereErrorResponse `json:"error,omitempty"`
+}
+
 // RoutesResponse contains the possible routes.
 type RoutesResponse struct {
 	// Routes in the possible routes between the origin and target.
@@ -72,65 +107,11 @@ type Route struct {
 	Sections []Section `json:"sections"`
 	// Contains a list of issues related to this route calculation.
 	Notices []Notice `json:"notices"`
+	// Handle of the route, used to recalculate it with RecalculateRoute.
+	// Only set when requested with RouteHandleReturnAttribute.
+	Handle string `json:"routeHandle"`
 }
 
-type RouteResponseNotice struct {
-	// Human-readable notice description.
-	Title string `json:"title"`
-	// Machine-readable notice code.
-	// See https://developer.here.com/documentation/routing-api/api-reference-swagger.html
-	// for possible values.
-	Code string `json:"code"`
-	// Describes the impact a notice has on the resource to which the notice is attached.
-	Severity NoticeSeverity `json:"severity"`
-	// Additional details about the notice.
-	// See https://developer.here.com/documentation/routing-api/api-reference-swagger.html
-	// for possible values.
-	Details []json.RawMessage `json:"details"`
-}
-
-type Notice struct {
-	// Human-readable notice description.
-	Title string `json:"title"`
-	// Machine-readable notice code.
-	// See https://developer.here.com/documentation/routing-api/api-reference-swagger.html
-	// for possible values.
-	Code string `json:"code"`
-	// Describes the impact a notice has on the resource to which the notice is attached.
-	Severity NoticeSeverity `json:"severity"`
-	// Additional details about the notice.
-	// See https://developer.here.com/documentation/routing-api/api-reference-swagger.html
-	// for possible values.
-	Details []json.RawMessage `json:"details"`
-}
-
-type VehicleNotice struct {
-	// Human-readable notice description.
-	Title string `json:"title"`
-	// Machine-readable notice code.
-	// See https://developer.here.com/documentation/routing-api/api-reference-swagger.html
-	// for possible values.
-	Code string `json:"code"`
-	// Describes the impact a notice has on the resource to which the notice is attached.
-	Severity NoticeSeverity `json:"severity"`
-	// Additional details about the notice.
-	// See https://developer.here.com/documentation/routing-api/api-reference-swagger.html
-	// for possible values.
-	Details []json.RawMessage `json:"details"`
-}
-
-type NoticeSeverity string
-
-const (
-	// CriticalNoticeSeverity is used to indicate that the notice must not be ignored,
-	// even if the type of notice is not known to the user.
-	// Any associated resource (e.g., route section) must not be used without further evaluation.
-	CriticalNoticeSeverity NoticeSeverity = "critical"
-	// InfoNoticeSeverity is used to indicate that the notice is for informative purposes,
-	// but does not affect usability of the route.
-	InfoNoticeSeverity NoticeSeverity = "info"
-)
-
 // Section with the information of the departure, arrival location and summary.
 type Section struct {
 	// ID of the section
@@ -149,6 +130,179 @@ type Section struct {
 	Notices []VehicleNotice `json:"notices"`
 	// Spans attached to a `Section` describing vehicle content.
 	Spans []Span `json:"spans"`
+	// Actions to take to complete the section. Only set when requested with ActionsReturnAttribute.
+	Actions []Action `json:"actions"`
+	// PostActions to take after arriving at the end of the section, e.g. waiting for the stop duration of a via
+	// waypoint.
+	PostActions []PostAction `json:"postActions"`
+	// Tolls to pay along the section. Only set when requested with TollsReturnAttribute.
+	Tolls []Toll `json:"tolls"`
+	// TollSystems collecting the tolls along the section, referenced by Toll.TollSystems.
+	TollSystems []TollSystem `json:"tollSystems"`
+	// TurnByTurnActions for guidance along the section.
+	// Only set when requested with TurnByTurnActionsReturnAttribute.
+	TurnByTurnActions []Action `json:"turnByTurnActions"`
+	// Transport used along the section, e.g. the line of a transit section.
+	Transport Transport `json:"transport"`
+	// TravelSummary contains the duration and length of a transit section.
+	// Only set when requested with TravelSummaryReturnAttribute.
+	TravelSummary Summary `json:"travelSummary"`
+	// Agency operating a transit section.
+	Agency *Agency `json:"agency"`
+	// IntermediateStops of a transit section, between its departure and arrival.
+	// Only set when requested with IntermediateReturnAttribute.
+	IntermediateStops []IntermediateStop `json:"intermediateStops"`
+}
+
+// Transport used along a section.
+type Transport struct {
+	// Mode of transport, e.g. "car", "pedestrian" or "bus".
+	Mode string `json:"mode"`
+	// Name of a transit line, e.g. "2".
+	Name string `json:"name"`
+	// Category of a transit line, e.g. "Bus".
+	Category string `json:"category"`
+	// Color of a transit line, e.g. "#FF0000".
+	Color string `json:"color"`
+	// TextColor of a transit line, e.g. "#FFFFFF".
+	TextColor string `json:"textColor"`
+	// Headsign of a transit line, usually the final destination.
+	Headsign string `json:"headsign"`
+	// ShortName of a transit line.
+	ShortName string `json:"shortName"`
+	// LongName of a transit line.
+	LongName string `json:"longName"`
+}
+
+// Agency operating a transit line.
+type Agency struct {
+	// ID of the agency.
+	ID string `json:"id"`
+	// Name of the agency.
+	Name string `json:"name"`
+	// Website of the agency.
+	Website string `json:"website"`
+}
+
+// IntermediateStop is a stop of a transit section between its departure and arrival.
+type IntermediateStop struct {
+	// Departure from the stop.
+	Departure VehicleDeparture `json:"departure"`
+	// Duration of the stop in seconds.
+	Duration int32 `json:"duration"`
+}
+
+// Action is a maneuver to take along a section, such as a turn.
+type Action struct {
+	// Action type, e.g. ActionTypeTurn.
+	Action ActionType `json:"action"`
+	// Duration of the action in seconds, i.e. until the next action.
+	Duration int32 `json:"duration"`
+	// Length of the action in meters, i.e. until the next action.
+	Length int32 `json:"length"`
+	// Instruction text in the requested language. Only set when requested with InstructionsReturnAttribute.
+	Instruction string `json:"instruction"`
+	// Offset of the action in the polyline of the section.
+	Offset int `json:"offset"`
+	// Direction of the action, e.g. left for a turn.
+	Direction ActionDirection `json:"direction"`
+	// Severity of the action, e.g. how sharp a turn is.
+	Severity ActionSeverity `json:"severity"`
+	// Exit number of a roundabout exit action.
+	Exit int `json:"exit"`
+	// TurnAngle in degrees of the action. Only set for turn-by-turn actions.
+	TurnAngle float64 `json:"turnAngle"`
+	// ExitSign of an exit action. Only set for turn-by-turn actions.
+	ExitSign *ExitInfo `json:"exitSign"`
+	// CurrentRoad before the action. Only set for turn-by-turn actions.
+	CurrentRoad *RoadInfo `json:"currentRoad"`
+	// NextRoad after the action. Only set for turn-by-turn actions.
+	NextRoad *RoadInfo `json:"nextRoad"`
+}
+
+// PostAction is an action to take after arriving at the end of a section.
+type PostAction struct {
+	// Action type, e.g. PostActionTypeWait.
+	Action PostActionType `json:"action"`
+	// Duration of the action in seconds.
+	Duration int32 `json:"duration"`
+	// ConsumablePower in kW of a charging action.
+	ConsumablePower float64 `json:"consumablePower"`
+	// ArrivalCharge in kWh when starting a charging action.
+	ArrivalCharge float64 `json:"arrivalCharge"`
+	// TargetCharge in kWh when finishing a charging action.
+	TargetCharge float64 `json:"targetCharge"`
+}
+
+// PostActionType is the type of a PostAction.
+type PostActionType string
+
+const (
+	// PostActionTypeWait is used for the stop duration of a via waypoint.
+	PostActionTypeWait PostActionType = "wait"
+	// PostActionTypeChargingSetup is used for the setup time at a charging station, before charging starts.
+	PostActionTypeChargingSetup PostActionType = "chargingSetup"
+	// PostActionTypeCharging is used for charging at a charging station.
+	PostActionTypeCharging PostActionType = "charging"
+)
+
+// ActionType is the type of an Action.
+// See https://www.here.com/docs/bundle/routing-api-v8-api-reference/page/index.html#tag/Routing/operation/calculateRoutes
+// for possible values.
+type ActionType string
+
+const (
+	ActionTypeDepart          ActionType = "depart"
+	ActionTypeArrive          ActionType = "arrive"
+	ActionTypeContinue        ActionType = "continue"
+	ActionTypeTurn            ActionType = "turn"
+	ActionTypeKeep            ActionType = "keep"
+	ActionTypeUTurn           ActionType = "uTurn"
+	ActionTypeExit            ActionType = "exit"
+	ActionTypeRamp            ActionType = "ramp"
+	ActionTypeEnterHighway    ActionType = "enterHighway"
+	ActionTypeContinueHighway ActionType = "continueHighway"
+	ActionTypeRoundaboutEnter ActionType = "roundaboutEnter"
+	ActionTypeRoundaboutPass  ActionType = "roundaboutPass"
+	ActionTypeRoundaboutExit  ActionType = "roundaboutExit"
+	ActionTypeBoard           ActionType = "board"
+	ActionTypeDeboard         ActionType = "deboard"
+)
+
+// ActionDirection is the direction of an Action.
+type ActionDirection string
+
+const (
+	ActionDirectionLeft   ActionDirection = "left"
+	ActionDirectionRight  ActionDirection = "right"
+	ActionDirectionMiddle ActionDirection = "middle"
+)
+
+// ActionSeverity is the severity of an Action, e.g. how sharp a turn is.
+type ActionSeverity string
+
+const (
+	ActionSeverityLight ActionSeverity = "light"
+	ActionSeverityQuite ActionSeverity = "quite"
+	ActionSeverityHeavy ActionSeverity = "heavy"
+)
+
+// RoadInfo describes a road of an Action.
+type RoadInfo struct {
+	// Type of the road, e.g. "highway", "rural" or "urban".
+	Type string `json:"type"`
+	// Name of the road in different languages.
+	Name []Name `json:"name"`
+	// Number of the road, e.g. "E6".
+	Number []Name `json:"number"`
+	// Toward is the destination the road leads to, as written on signs.
+	Toward []Name `json:"toward"`
+}
+
+// ExitInfo describes the exit sign of an Action.
+type ExitInfo struct {
+	// Number of the exit.
+	Number []Name `json:"number"`
 }
 
 type Span struct {
@@ -160,6 +314,81 @@ type Span struct {
 	MaxSpeed MaxSpeedEither `json:"maxSpeed"`
 	// Spans attached to a Section describing vehicle content.
 	Offset int `json:"offset"`
+	// SpeedLimit is the legal speed limit in meters per second, or "unlimited".
+	SpeedLimit MaxSpeedEither `json:"speedLimit"`
+	// FunctionalClass of the road, from 1 for major roads to 5 for minor roads. Zero if not requested.
+	FunctionalClass int `json:"functionalClass"`
+	// SegmentID of the road segment, e.g. "+here:cm:segment:76771992".
+	SegmentID string `json:"segmentId"`
+	// CountryCode of the span as an ISO 3166-1 alpha-3 code, e.g. "DEU".
+	CountryCode string `json:"countryCode"`
+	// StateCode of the span, e.g. "CA".
+	StateCode string `json:"stateCode"`
+	// TruckAttributes of the road for trucks.
+	TruckAttributes []VehicleAttribute `json:"truckAttributes"`
+	// CarAttributes of the road for cars.
+	CarAttributes []VehicleAttribute `json:"carAttributes"`
+	// StreetAttributes of the road, e.g. whether it is a tunnel.
+	StreetAttributes []StreetAttribute `json:"streetAttributes"`
+	// Duration of the span in seconds.
+	Duration int32 `json:"duration"`
+	// DynamicSpeedInfo of the span, based on traffic.
+	DynamicSpeedInfo *DynamicSpeedInfo `json:"dynamicSpeedInfo"`
+	// TollSystems of the span, as indices into Section.TollSystems.
+	TollSystems []int `json:"tollSystems"`
+	// RouteNumbers of the road, e.g. "A7".
+	RouteNumbers []RouteNumber `json:"routeNumbers"`
+	// Notices of the span, as indices into Section.Notices.
+	Notices []int `json:"notices"`
+}
+
+// VehicleAttribute is an attribute of a road for a vehicle type, see Span.CarAttributes and Span.TruckAttributes.
+type VehicleAttribute string
+
+const (
+	// VehicleAttributeOpen is used when the road is open for the vehicle type.
+	VehicleAttributeOpen VehicleAttribute = "open"
+	// VehicleAttributeTollRoad is used when the vehicle type has to pay toll on the road.
+	VehicleAttributeTollRoad VehicleAttribute = "tollRoad"
+)
+
+// StreetAttribute is an attribute of a road, see Span.StreetAttributes.
+type StreetAttribute string
+
+const (
+	StreetAttributeRightDrivingSide  StreetAttribute = "rightDrivingSide"
+	StreetAttributeDirtRoad          StreetAttribute = "dirtRoad"
+	StreetAttributeTunnel            StreetAttribute = "tunnel"
+	StreetAttributeBridge            StreetAttribute = "bridge"
+	StreetAttributeRamp              StreetAttribute = "ramp"
+	StreetAttributeControlledAccess  StreetAttribute = "controlledAccess"
+	StreetAttributeMotorway          StreetAttribute = "motorway"
+	StreetAttributeRoundabout        StreetAttribute = "roundabout"
+	StreetAttributeUnderConstruction StreetAttribute = "underConstruction"
+	StreetAttributeDividedRoad       StreetAttribute = "dividedRoad"
+	StreetAttributePrivateRoad       StreetAttribute = "privateRoad"
+)
+
+// DynamicSpeedInfo describes the speed on a span, based on traffic.
+type DynamicSpeedInfo struct {
+	// TrafficSpeed in meters per second, with current traffic.
+	TrafficSpeed float64 `json:"trafficSpeed"`
+	// BaseSpeed in meters per second, without traffic.
+	BaseSpeed float64 `json:"baseSpeed"`
+	// TurnTime in seconds, the time to turn from the previous span into the span.
+	TurnTime int32 `json:"turnTime"`
+}
+
+// RouteNumber is a number of a road, e.g. "A7".
+type RouteNumber struct {
+	// Value of the route number.
+	Value string `json:"value"`
+	// Language in BCP47 format.
+	Language string `json:"language"`
+	// Direction of the road, e.g. "north". Only set for roads with a direction.
+	Direction string `json:"direction"`
+	// RouteType of the road, from 1 for major roads to 6 for minor roads.
+	RouteType int `json:"routeType"`
 }
 
 // MaxSpeedEither holds either a speed or unlimited is true if speed is unlimited.
@@ -171,6 +400,14 @@ type MaxSpeedEither struct {
 	Unlimited bool
 }
 
+// MarshalJSON encodes the speed as a number, or as "unlimited" if speed is unlimited.
+func (m MaxSpeedEither) MarshalJSON() ([]byte, error) {
+	if m.Unlimited {
+		return json.Marshal("unlimited")
+	}
+	return json.Marshal(m.MaxSpeed)
+}
+
 func (m *MaxSpeedEither) UnmarshalJSON(b []byte) error {
 	if b[0] == '"' {
 		// Value is a string
@@ -196,18 +433,83 @@ type Name struct {
 	Value string `json:"value"`
 }
 
+// ViaIndex returns the index in RoutesRequest.Via or RoutesRequest.ViaWaypoints of the via waypoint that the
+// section ends at. The boolean is false if the section ends at the destination, or at a place that does not
+// correspond to a waypoint.
+func (s *Section) ViaIndex(numVia int) (int, bool) {
+	waypoint := s.Arrival.Place.Waypoint
+	if waypoint == nil || *waypoint < 1 || *waypoint > numVia {
+		return 0, false
+	}
+	return *waypoint - 1, true
+}
+
 type VehicleDeparture struct {
 	Place Place `json:"place"`
+	// Time of departure or arrival at the place, with the UTC offset of the place.
+	Time time.Time `json:"time"`
+	// Charge in kWh of the battery at the place. Only set for EV routes.
+	Charge *float64 `json:"charge"`
+	// Delay in seconds of a transit departure or arrival, compared to the schedule.
+	Delay int32 `json:"delay"`
 }
 
+const (
+	// PlaceTypeChargingStation is the type of a place where an EV route stops to charge.
+	PlaceTypeChargingStation = "chargingStation"
+	// PlaceTypeStation is the type of a transit stop.
+	PlaceTypeStation = "station"
+)
+
 // Place with lat and long info on where the place is.
 type Place struct {
 	// Type is the struct
 	Type string `json:"type"`
+	// ID of the place, e.g. of a charging station.
+	ID string `json:"id"`
+	// Name of the place, e.g. of a charging station.
+	Name string `json:"name"`
+	// Attributes of a charging station. Only set for places of type PlaceTypeChargingStation.
+	ChargingStationAttributes *ChargingStationAttributes `json:"attributes"`
 	// Location in lat and long
 	Location GeoWaypoint `json:"location"`
 	// OriginalLocation in lat and long
 	OriginalLocation GeoWaypoint `json:"originalLocation"`
+	// Waypoint is the index of the waypoint in the request that corresponds to the place, where 0 is the origin,
+	// 1 to len(Via) are the via waypoints and len(Via)+1 is the destination.
+	// Nil if the place does not correspond to a waypoint, e.g. for pass through waypoints.
+	Waypoint *int `json:"waypoint"`
+	// SideOfStreet of the place relative to the driving direction, "left" or "right".
+	// Only set if the place is not on the street itself.
+	SideOfStreet string `json:"sideOfStreet"`
+	// Code of a transit stop, e.g. as shown on signs.
+	Code string `json:"code"`
+	// Platform of a transit stop.
+	Platform string `json:"platform"`
+	// WheelchairAccessible tells if a transit stop is accessible by wheelchair, e.g. "yes", "no" or "limited".
+	WheelchairAccessible string `json:"wheelchairAccessible"`
+}
+
+// ChargingStationAttributes describes the charging point used at a charging station.
+type ChargingStationAttributes struct {
+	// Power in kW.
+	Power float64 `json:"power"`
+	// Current in A.
+	Current float64 `json:"current"`
+	// Voltage in V.
+	Voltage float64 `json:"voltage"`
+	// SupplyType of the charging point, e.g. "dc".
+	SupplyType string `json:"supplyType"`
+	// ConnectorType of the charging point.
+	ConnectorType ChargingConnector `json:"connectorType"`
+}
+
+// ChargingConnector is a connector of a charging point.
+type ChargingConnector struct {
+	// ID of the connector type.
+	ID string `json:"id"`
+	// Name of the connector type.
+	Name string `json:"name"`
 }
 
 // Summary contains the duration and length info.
@@ -218,6 +520,8 @@ type Summary struct {
 	Length int32 `json:"length"`
 	// BaseDuration is the duration without dynamic traffic information
 	BaseDuration int32 `json:"baseDuration"`
+	// Consumption in kWh. Only set for EV routes.
+	Consumption float64 `json:"consumption"`
 }
 
 // Polyline of a route section, encoded as a  Flexible Polyline.
@@ -7,6 +7,7 @@ import (
 	"path"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"gotest.tools/v3/assert"
@@ -34,6 +35,7 @@ func TestUnmarshalRoute(t *testing.T) {
 									},
 									OriginalLocation: GeoWaypoint{},
 								},
+								Time: parseTime(t, "2019-12-09T16:05:05+01:00"),
 							},
 							Departure: VehicleDeparture{
 								Place: Place{
@@ -44,13 +46,15 @@ func TestUnmarshalRoute(t *testing.T) {
 									},
 									OriginalLocation: GeoWaypoint{},
 								},
+								Time: parseTime(t, "2019-12-09T16:03:02+01:00"),
 							},
 							Summary: Summary{
 								Duration:     123,
 								Length:       538,
 								BaseDuration: 0,
 							},
-							Polyline: "",
+							Polyline:  "",
+							Transport: Transport{Mode: "car"},
 						},
 					},
 				},
@@ -77,6 +81,7 @@ func TestUnmarshalRoute(t *testing.T) {
 									},
 									OriginalLocation: GeoWaypoint{},
 								},
+								Time: parseTime(t, "2019-12-09T11:15:43+01:00"),
 							},
 							Departure: VehicleDeparture{
 								Place: Place{
@@ -87,10 +92,12 @@ func TestUnmarshalRoute(t *testing.T) {
 									},
 									OriginalLocation: GeoWaypoint{},
 								},
+								Time: parseTime(t, "2019-12-09T11:13:51+01:00"),
 							},
 							Summary: Summary{},
 							Polyline: "BGwynmkDu39wZvBtFAA3InfAAvHrdAAvHvbAAoGzF0FnGoGvHsOvRAA8L3NAAkSnVAAo" +
 								"GjIsEzFAAgFvHkDrJAAwHrJoVvb0ezoBAAjInVAA3N_iBAAzJ_Z",
+							Transport: Transport{Mode: "car"},
 						},
 					},
 				},
@@ -123,6 +130,7 @@ func TestUnmarshalRoute(t *testing.T) {
 											Long: 17.0388039,
 										},
 									},
+									Time: parseTime(t, "2021-11-01T10:27:04+01:00"),
 								},
 								Departure: VehicleDeparture{
 									Place: Place{
@@ -136,6 +144,7 @@ func TestUnmarshalRoute(t *testing.T) {
 											Long: 17.1615459,
 										},
 									},
+									Time: parseTime(t, "2021-11-01T10:00:00+01:00"),
 								},
 								Summary: Summary{
 									Duration:     1624,
@@ -169,6 +178,88 @@ func TestUnmarshalRoute(t *testing.T) {
 										},
 									},
 								},
+								Actions: []Action{
+									{
+										Action:      ActionTypeDepart,
+										Duration:    282,
+										Length:      3111,
+										Instruction: "Head toward ulica Eugeniusza Kwiatkowskiego on ulica Opolska (94). Go for 3.1 km.",
+									},
+									{
+										Action:   ActionTypeRoundaboutExit,
+										Duration: 931,
+										Length:   8299,
+										Instruction: "Take the 2nd exit from roundabout onto ulica Wrocławska (94) " +
+											"toward Wrocław Księże Małe. Go for 8.3 km.",
+										Offset:    110,
+										Direction: ActionDirectionRight,
+										Exit:      2,
+									},
+									{
+										Action:      ActionTypeKeep,
+										Duration:    197,
+										Length:      1178,
+										Instruction: "Keep right onto ulica gen. Romualda Traugutta. Go for 1.2 km.",
+										Offset:      439,
+										Direction:   ActionDirectionRight,
+									},
+									{
+										Action:      ActionTypeTurn,
+										Duration:    62,
+										Length:      103,
+										Instruction: "Turn left onto plac gen. Walerego Wróblewskiego. Go for 103 m.",
+										Offset:      501,
+										Direction:   ActionDirectionLeft,
+										Severity:    ActionSeverityQuite,
+									},
+									{
+										Action:      ActionTypeTurn,
+										Duration:    16,
+										Length:      44,
+										Instruction: "Turn left onto ulica Kujawska (98). Go for 44 m.",
+										Offset:      504,
+										Direction:   ActionDirectionLeft,
+										Severity:    ActionSeverityQuite,
+									},
+									{
+										Action:      ActionTypeTurn,
+										Duration:    78,
+										Length:      432,
+										Instruction: "Turn right onto ulica gen. Romualda Traugutta. Go for 432 m.",
+										Offset:      507,
+										Direction:   ActionDirectionRight,
+										Severity:    ActionSeverityQuite,
+									},
+									{
+										Action:      ActionTypeContinue,
+										Duration:    9,
+										Length:      55,
+										Instruction: "Continue on ulica Oławska. Go for 55 m.",
+										Offset:      519,
+									},
+									{
+										Action:      ActionTypeKeep,
+										Duration:    29,
+										Length:      134,
+										Instruction: "Keep right toward plac Dominikański. Go for 134 m.",
+										Offset:      521,
+										Direction:   ActionDirectionRight,
+									},
+									{
+										Action:      ActionTypeTurn,
+										Duration:    20,
+										Length:      95,
+										Instruction: "Turn right onto plac Dominikański. Go for 95 m.",
+										Offset:      527,
+										Direction:   ActionDirectionRight,
+										Severity:    ActionSeverityQuite,
+									},
+									{
+										Action:      ActionTypeArrive,
+										Instruction: "Arrive at plac Dominikański.",
+										Offset:      536,
+									},
+								},
 								Polyline: "BG6m_phDgnu3gBif3zBgKvR0K3S4NjXwHvM0FrJoGzK8G7LsJ_O0KvR8Q3c0FrJ0jBj6Bs" +
 									"YnpBwR3c8a_sBsJzPoVzjBgUvgBgFjIsE7GoGzKwHjNgKjS0F_J8G7LsJnQ8Q3coG_JoQ7a4N7Vw" +
 									"MzUwMnVkN7Vs7B_jDoQ7akI3NkIrO0KjSwHvMsEvHgFjIwHjNkN7VoG_JsEvHoGzK8GzKoG_J0F3" +
@@ -198,6 +289,7 @@ func TestUnmarshalRoute(t *testing.T) {
 									"oG3IwR_YkInLkIzK8L_OkNzP4IzKgK7LwHjI4DrEkIT0FAsEAgFAoGUsEU0FoBoG8B4D8BsEwCwH" +
 									"gF4IgFsE8B4InpBoLnkBkDzFrEzFrEzFzK3NokBjzCwHvRgK3XkI_T8G3IoGjN4I3XsE_OkDjSwH" +
 									"jrBkIr2BwCrOsEzjBoBvMgF_O8BvH8B3I4D3X4Dna4DjhBkD3I8B_EwCrE4D3DkDjDsJA8GnB8GvCkInD",
+								Transport: Transport{Mode: "truck"},
 							},
 						},
 					},
@@ -277,6 +369,7 @@ func TestUnmarshalRoute(t *testing.T) {
 										},
 									},
 								},
+								Transport: Transport{Mode: "car"},
 							},
 						},
 					},
@@ -285,6 +378,378 @@ func TestUnmarshalRoute(t *testing.T) {
 			rawMessageEqual(),
 		)
 	})
+	t.Run("route-with-actions.json", func(t *testing.T) {
+		t.Parallel()
+		resp := unmarshalRouteResponseFromFile(t, "route-with-actions.json")
+		assert.DeepEqual(t, resp, RoutesResponse{
+			Routes: []Route{
+				{
+					ID: "0f3b0e2a-8f53-4f4a-9a1c-5f1a3b3c7d21",
+					Sections: []Section{
+						{
+							ID:   "f3b0a8c4-3b5e-4d0e-9b8e-0c1e6a2b7d44",
+							Type: "vehicle",
+							Actions: []Action{
+								{
+									Action:      ActionTypeDepart,
+									Duration:    42,
+									Length:      310,
+									Instruction: "Head toward Lindholmsallén on Lindholmspiren. Go for 310 m.",
+									Offset:      0,
+								},
+								{
+									Action:      ActionTypeTurn,
+									Duration:    18,
+									Length:      120,
+									Instruction: "Turn right onto Lindholmsallén. Go for 120 m.",
+									Offset:      5,
+									Direction:   ActionDirectionRight,
+									Severity:    ActionSeverityQuite,
+								},
+								{
+									Action:      ActionTypeRoundaboutExit,
+									Duration:    25,
+									Length:      200,
+									Instruction: "Take the 2nd exit from roundabout onto E45.",
+									Offset:      9,
+									Direction:   ActionDirectionRight,
+									Severity:    ActionSeverityLight,
+									Exit:        2,
+								},
+								{
+									Action:      ActionTypeArrive,
+									Instruction: "Arrive at your destination.",
+									Offset:      14,
+								},
+							},
+							TurnByTurnActions: []Action{
+								{
+									Action:    ActionTypeTurn,
+									Duration:  18,
+									Length:    120,
+									Offset:    5,
+									Direction: ActionDirectionRight,
+									Severity:  ActionSeverityQuite,
+									TurnAngle: 88.5,
+									CurrentRoad: &RoadInfo{
+										Type: "urban",
+										Name: []Name{{Value: "Lindholmspiren", Language: "sv"}},
+									},
+									NextRoad: &RoadInfo{
+										Type:   "urban",
+										Name:   []Name{{Value: "Lindholmsallén", Language: "sv"}},
+										Number: []Name{{Value: "E45", Language: "sv"}},
+										Toward: []Name{{Value: "Göteborg", Language: "sv"}},
+									},
+									ExitSign: &ExitInfo{
+										Number: []Name{{Value: "72", Language: "sv"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	})
+	t.Run("route-with-vias.json", func(t *testing.T) {
+		t.Parallel()
+		resp := unmarshalRouteResponseFromFile(t, "route-with-vias.json")
+		waypoint := func(i int) *int {
+			return &i
+		}
+		assert.DeepEqual(t, resp, RoutesResponse{
+			Routes: []Route{
+				{
+					ID: "2b6f1f1e-5b0e-4f3e-8f5a-6c9b7a1d2e30",
+					Sections: []Section{
+						{
+							ID:   "7d1c2b3a-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+							Type: "vehicle",
+							Departure: VehicleDeparture{
+								Place: Place{
+									Type:     "place",
+									Location: GeoWaypoint{Lat: 57.707752, Long: 11.949767},
+									Waypoint: waypoint(0),
+								},
+								Time: parseTime(t, "2021-11-01T10:00:00+01:00"),
+							},
+							Arrival: VehicleDeparture{
+								Place: Place{
+									Type:             "place",
+									Location:         GeoWaypoint{Lat: 57.695538, Long: 11.992594},
+									OriginalLocation: GeoWaypoint{Lat: 57.695601, Long: 11.992611},
+									Waypoint:         waypoint(1),
+									SideOfStreet:     "right",
+								},
+								Time: parseTime(t, "2021-11-01T10:12:30+01:00"),
+							},
+							PostActions: []PostAction{{Action: PostActionTypeWait, Duration: 600}},
+							Summary:     Summary{Duration: 750, Length: 4210, BaseDuration: 700},
+						},
+						{
+							ID:   "8e2d3c4b-5f6a-4b7c-9d8e-0f1a2b3c4d5e",
+							Type: "vehicle",
+							Departure: VehicleDeparture{
+								Place: Place{
+									Type:     "place",
+									Location: GeoWaypoint{Lat: 57.695538, Long: 11.992594},
+									Waypoint: waypoint(1),
+								},
+								Time: parseTime(t, "2021-11-01T10:22:30+01:00"),
+							},
+							Arrival: VehicleDeparture{
+								Place: Place{
+									Type:     "place",
+									Location: GeoWaypoint{Lat: 59.337492, Long: 18.063672},
+									Waypoint: waypoint(2),
+								},
+								Time: parseTime(t, "2021-11-01T14:40:00+01:00"),
+							},
+							Summary: Summary{Duration: 15450, Length: 468000, BaseDuration: 15000},
+						},
+					},
+				},
+			},
+		})
+		via, ok := resp.Routes[0].Sections[0].ViaIndex(1)
+		assert.Check(t, ok)
+		assert.Equal(t, 0, via)
+		_, ok = resp.Routes[0].Sections[1].ViaIndex(1)
+		assert.Check(t, !ok)
+	})
+	t.Run("route-with-tolls.json", func(t *testing.T) {
+		t.Parallel()
+		resp := unmarshalRouteResponseFromFile(t, "route-with-tolls.json")
+		assert.DeepEqual(t, resp, RoutesResponse{
+			Routes: []Route{
+				{
+					ID: "5c0f3e5a-0a0b-4c1d-9e2f-3a4b5c6d7e8f",
+					Sections: []Section{
+						{
+							ID:      "6d1a4f6b-1b1c-4d2e-8f3a-4b5c6d7e8f90",
+							Type:    "vehicle",
+							Summary: Summary{Duration: 5400, Length: 120000, BaseDuration: 5200},
+							Tolls: []Toll{
+								{
+									CountryCode: "DEU",
+									TollSystem:  "TOLL COLLECT",
+									TollSystems: []int{0},
+									Fares: []TollFare{
+										{
+											ID:             "fare-1",
+											Name:           "TOLL COLLECT",
+											Price:          Price{Type: "value", Currency: "EUR", Value: 24.5},
+											ConvertedPrice: &Price{Type: "value", Currency: "SEK", Value: 275.2},
+											Reason:         "toll",
+											PaymentMethods: []PaymentMethod{
+												PaymentMethodCash,
+												PaymentMethodCreditCard,
+												PaymentMethodTransponder,
+											},
+										},
+									},
+								},
+								{
+									CountryCode: "DNK",
+									TollSystem:  "STOREBAELT",
+									TollSystems: []int{1},
+									Fares: []TollFare{
+										{
+											ID:             "fare-2",
+											Name:           "Storebaelt cash",
+											Price:          Price{Type: "value", Currency: "DKK", Value: 960},
+											ConvertedPrice: &Price{Type: "value", Currency: "SEK", Value: 1470},
+											Reason:         "toll",
+											PaymentMethods: []PaymentMethod{PaymentMethodCash},
+										},
+										{
+											ID:             "fare-3",
+											Name:           "Storebaelt transponder",
+											Price:          Price{Type: "value", Currency: "DKK", Value: 768},
+											ConvertedPrice: &Price{Type: "value", Currency: "SEK", Value: 1176},
+											Reason:         "toll",
+											PaymentMethods: []PaymentMethod{PaymentMethodTransponder},
+										},
+									},
+									TollCollectionLocations: []TollCollectionLocation{
+										{Name: "Storebaelt", Location: GeoWaypoint{Lat: 55.3331, Long: 11.0339}},
+									},
+								},
+							},
+							TollSystems: []TollSystem{
+								{Name: "TOLL COLLECT", Language: "de"},
+								{Name: "STOREBAELT", Language: "da"},
+							},
+						},
+					},
+				},
+			},
+		})
+		assert.DeepEqual(t, map[string]float64{"SEK": 275.2 + 1176}, resp.Routes[0].TollCosts())
+	})
+
+	t.Run("route-with-span-attributes.json", func(t *testing.T) {
+		t.Parallel()
+		resp := unmarshalRouteResponseFromFile(t, "route-with-span-attributes.json")
+		assert.DeepEqual(t, resp.Routes[0].Sections[0].Spans, []Span{
+			{
+				Offset:           0,
+				Length:           3000,
+				Duration:         240,
+				SpeedLimit:       MaxSpeedEither{MaxSpeed: 13.8888893},
+				FunctionalClass:  4,
+				SegmentID:        "+here:cm:segment:76771992",
+				CountryCode:      "DEU",
+				StateCode:        "HE",
+				CarAttributes:    []VehicleAttribute{VehicleAttributeOpen},
+				TruckAttributes:  []VehicleAttribute{VehicleAttributeOpen},
+				StreetAttributes: []StreetAttribute{StreetAttributeRightDrivingSide, StreetAttributeBridge},
+				DynamicSpeedInfo: &DynamicSpeedInfo{TrafficSpeed: 11.1, BaseSpeed: 12.5, TurnTime: 5},
+				Notices:          []int{0},
+			},
+			{
+				Offset:          2,
+				Length:          20000,
+				Duration:        1020,
+				SpeedLimit:      MaxSpeedEither{Unlimited: true},
+				FunctionalClass: 1,
+				SegmentID:       "-here:cm:segment:76771993",
+				CountryCode:     "DEU",
+				StateCode:       "HE",
+				CarAttributes:   []VehicleAttribute{VehicleAttributeOpen, VehicleAttributeTollRoad},
+				TruckAttributes: []VehicleAttribute{VehicleAttributeOpen, VehicleAttributeTollRoad},
+				StreetAttributes: []StreetAttribute{
+					StreetAttributeRightDrivingSide,
+					StreetAttributeControlledAccess,
+					StreetAttributeMotorway,
+					StreetAttributeDividedRoad,
+				},
+				DynamicSpeedInfo: &DynamicSpeedInfo{TrafficSpeed: 19.6, BaseSpeed: 22.2, TurnTime: 0},
+				TollSystems:      []int{0},
+				RouteNumbers:     []RouteNumber{{Value: "A5", Language: "de", Direction: "north", RouteType: 1}},
+			},
+		})
+	})
+}
+
+func TestUnmarshalRoute_ChargingStations(t *testing.T) {
+	t.Parallel()
+	resp := unmarshalRouteResponseFromFile(t, "route-with-charging-stations.json")
+	charge := func(v float64) *float64 {
+		return &v
+	}
+	chargingStation := Place{
+		Type:     PlaceTypeChargingStation,
+		ID:       "here:pds:place:752u6cvz-5d6e7f8a9b0c",
+		Name:     "Ionity Jönköping",
+		Location: GeoWaypoint{Lat: 57.7815, Long: 14.1562},
+	}
+	chargingStationWithAttributes := chargingStation
+	chargingStationWithAttributes.ChargingStationAttributes = &ChargingStationAttributes{
+		Power:      350,
+		Current:    500,
+		Voltage:    920,
+		SupplyType: "dc",
+		ConnectorType: ChargingConnector{
+			ID:   "33",
+			Name: "IEC 62196-3 Type 2 Combo (CCS)",
+		},
+	}
+	assert.DeepEqual(t, resp, RoutesResponse{
+		Routes: []Route{
+			{
+				ID: "0b2c3d4e-5f60-4718-8293-a4b5c6d7e8f9",
+				Sections: []Section{
+					{
+						ID:   "1c3d4e5f-6071-4829-93a4-b5c6d7e8f90a",
+						Type: "vehicle",
+						Departure: VehicleDeparture{
+							Place: Place{
+								Type:             "place",
+								Location:         GeoWaypoint{Lat: 57.707752, Long: 11.949767},
+								OriginalLocation: GeoWaypoint{Lat: 57.707752, Long: 11.949767},
+							},
+							Time:   parseTime(t, "2021-11-01T08:00:00+01:00"),
+							Charge: charge(300),
+						},
+						Arrival: VehicleDeparture{
+							Place:  chargingStationWithAttributes,
+							Time:   parseTime(t, "2021-11-01T11:10:00+01:00"),
+							Charge: charge(62.5),
+						},
+						Summary: Summary{Duration: 11400, Length: 150000, BaseDuration: 11000, Consumption: 237.5},
+						PostActions: []PostAction{
+							{Action: PostActionTypeChargingSetup, Duration: 300},
+							{
+								Action:          PostActionTypeCharging,
+								Duration:        2700,
+								ConsumablePower: 350,
+								ArrivalCharge:   62.5,
+								TargetCharge:    320,
+							},
+						},
+					},
+					{
+						ID:   "2d4e5f60-7182-4a3b-a4b5-c6d7e8f90a1b",
+						Type: "vehicle",
+						Departure: VehicleDeparture{
+							Place:  chargingStation,
+							Time:   parseTime(t, "2021-11-01T12:00:00+01:00"),
+							Charge: charge(320),
+						},
+						Arrival: VehicleDeparture{
+							Place: Place{
+								Type:             "place",
+								Location:         GeoWaypoint{Lat: 59.337492, Long: 18.063672},
+								OriginalLocation: GeoWaypoint{Lat: 59.337492, Long: 18.063672},
+							},
+							Time:   parseTime(t, "2021-11-01T15:30:00+01:00"),
+							Charge: charge(84),
+						},
+						Summary: Summary{Duration: 12600, Length: 170000, BaseDuration: 12200, Consumption: 236},
+					},
+				},
+			},
+		},
+	})
+	stops := resp.Routes[0].ChargingStops()
+	assert.Equal(t, 1, len(stops))
+	assert.Equal(t, &resp.Routes[0].Sections[0], stops[0])
+	assert.Equal(t, 473.5, resp.Routes[0].Summary().Consumption)
+}
+
+func TestMaxSpeedEither_JSON(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		speed    MaxSpeedEither
+		expected string
+	}{
+		{name: "speed", speed: MaxSpeedEither{MaxSpeed: 13.5}, expected: `13.5`},
+		{name: "unlimited", speed: MaxSpeedEither{Unlimited: true}, expected: `"unlimited"`},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b, err := json.Marshal(tt.speed)
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, string(b))
+			var got MaxSpeedEither
+			assert.NilError(t, json.Unmarshal(b, &got))
+			assert.Equal(t, tt.speed, got)
+		})
+	}
+	t.Run("span", func(t *testing.T) {
+		t.Parallel()
+		// A marshaled span has the API form of its speeds, and not the fields of MaxSpeedEither.
+		b, err := json.Marshal(Span{MaxSpeed: MaxSpeedEither{MaxSpeed: 13.5}, SpeedLimit: MaxSpeedEither{Unlimited: true}})
+		assert.NilError(t, err)
+		var got map[string]json.RawMessage
+		assert.NilError(t, json.Unmarshal(b, &got))
+		assert.Equal(t, `13.5`, string(got["maxSpeed"]))
+		assert.Equal(t, `"unlimited"`, string(got["speedLimit"]))
+	})
 }
 
 func unmarshalRouteResponseFromFile(t *testing.T, filename string) RoutesResponse {
@@ -295,6 +760,12 @@ func unmarshalRouteResponseFromFile(t *testing.T, filename string) RoutesRespons
 	return resp
 }
 
+func parseTime(t *testing.T, value string) time.Time {
+	result, err := time.Parse(time.RFC3339, value)
+	assert.NilError(t, err)
+	return result
+}
+
 func rawMessageEqual() cmp.Option {
 	return cmp.Comparer(func(a, b json.RawMessage) bool {
 		am := make(map[string]interface{})
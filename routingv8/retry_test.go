@@ -0,0 +1,100 @@
+package routingv8_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/internal/httpmock"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestClient_RetryPolicy(t *testing.T) {
+	t.Parallel()
+	policy := routingv8.RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond}
+	routes := func(ctx context.Context, client *routingv8.Client) error {
+		_, err := client.Routing.Routes(ctx, &routingv8.RoutesRequest{
+			Origin:        routingv8.GeoWaypoint{Lat: 1, Long: 2},
+			Destination:   routingv8.GeoWaypoint{Lat: 3, Long: 4},
+			TransportMode: routingv8.TransportModeCar,
+		})
+		return err
+	}
+	matrix := func(ctx context.Context, client *routingv8.Client) error {
+		_, err := client.Matrix.CalculateMatrix(ctx, &routingv8.CalculateMatrixRequest{
+			Body: &routingv8.CalculateMatrixBody{
+				Origins:          []*routingv8.GeoWaypoint{{Lat: 1, Long: 2}},
+				RegionDefinition: routingv8.RegionDefinition{Type: routingv8.RegionTypeWorld},
+			},
+		})
+		return err
+	}
+	for _, tt := range []struct {
+		name                 string
+		policy               routingv8.RetryPolicy
+		retryCalculateMatrix bool
+		statusCodes          []int
+		call                 func(context.Context, *routingv8.Client) error
+		expectedErr          string
+		expectedAttempts     int
+	}{
+		{
+			name:             "retries disabled by default",
+			statusCodes:      []int{503},
+			call:             routes,
+			expectedErr:      "StatusCode: 503",
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retry until success",
+			policy:           policy,
+			statusCodes:      []int{503, 429},
+			call:             routes,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "matrix not retried by default",
+			policy:           policy,
+			statusCodes:      []int{503},
+			call:             matrix,
+			expectedErr:      "StatusCode: 503",
+			expectedAttempts: 1,
+		},
+		{
+			name:                 "matrix opt-in",
+			policy:               policy,
+			retryCalculateMatrix: true,
+			statusCodes:          []int{503},
+			call:                 matrix,
+			expectedAttempts:     2,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock := &httpmock.Client{
+				StatusCodes: tt.statusCodes,
+				Body: func(*http.Request) string {
+					return `{"routes":[],"matrixId":"id"}`
+				},
+			}
+			client := routingv8.NewClient(mock)
+			client.RetryPolicy = tt.policy
+			client.RetryCalculateMatrix = tt.retryCalculateMatrix
+			err := tt.call(context.Background(), client)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NilError(t, err)
+			}
+			bodies := mock.Bodies()
+			assert.Equal(t, tt.expectedAttempts, len(bodies))
+			for _, body := range bodies {
+				// The body is sent again on each attempt.
+				assert.Equal(t, bodies[0], body)
+			}
+		})
+	}
+}
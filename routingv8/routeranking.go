@@ -0,0 +1,52 @@
+package routingv8
+
+import "sort"
+
+// RouteCostFunc returns the cost of a route. Routes with lower cost are ranked first.
+type RouteCostFunc func(route *Route) float64
+
+// Summary returns the summary of the route, as the sum of the summaries of its sections.
+func (r *Route) Summary() Summary {
+	var summary Summary
+	for _, section := range r.Sections {
+		summary.Duration += section.Summary.Duration
+		summary.Length += section.Summary.Length
+		summary.BaseDuration += section.Summary.BaseDuration
+		summary.Consumption += section.Summary.Consumption
+	}
+	return summary
+}
+
+// RankRoutes returns the routes ordered by increasing cost. Routes with equal cost keep their order in the
+// response, where the best route according to the routing mode is first.
+// The given slice is not modified.
+func RankRoutes(routes []Route, cost RouteCostFunc) []Route {
+	costs := make([]float64, len(routes))
+	indices := make([]int, len(routes))
+	for i := range routes {
+		costs[i] = cost(&routes[i])
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return costs[indices[a]] < costs[indices[b]]
+	})
+	ranked := make([]Route, 0, len(routes))
+	for _, i := range indices {
+		ranked = append(ranked, routes[i])
+	}
+	return ranked
+}
+
+// RankRoutesByDuration returns the routes ordered by increasing duration.
+func RankRoutesByDuration(routes []Route) []Route {
+	return RankRoutes(routes, func(route *Route) float64 {
+		return float64(route.Summary().Duration)
+	})
+}
+
+// RankRoutesByLength returns the routes ordered by increasing length.
+func RankRoutesByLength(routes []Route) []Route {
+	return RankRoutes(routes, func(route *Route) float64 {
+		return float64(route.Summary().Length)
+	})
+}
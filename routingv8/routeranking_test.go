@@ -0,0 +1,59 @@
+package routingv8_test
+
+import (
+	"testing"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestRankRoutes(t *testing.T) {
+	t.Parallel()
+	routes := []routingv8.Route{
+		{
+			ID: "fastest",
+			Sections: []routingv8.Section{
+				{Summary: routingv8.Summary{Duration: 100, Length: 2000}},
+				{Summary: routingv8.Summary{Duration: 50, Length: 1500}},
+			},
+		},
+		{
+			ID: "shortest",
+			Sections: []routingv8.Section{
+				{Summary: routingv8.Summary{Duration: 200, Length: 1000}},
+			},
+		},
+		{
+			ID: "same duration as shortest",
+			Sections: []routingv8.Section{
+				{Summary: routingv8.Summary{Duration: 200, Length: 4000}},
+			},
+		},
+	}
+	ids := func(routes []routingv8.Route) []string {
+		result := make([]string, 0, len(routes))
+		for _, route := range routes {
+			result = append(result, route.ID)
+		}
+		return result
+	}
+	assert.DeepEqual(
+		t,
+		[]string{"fastest", "shortest", "same duration as shortest"},
+		ids(routingv8.RankRoutesByDuration(routes)),
+	)
+	assert.DeepEqual(
+		t,
+		[]string{"shortest", "fastest", "same duration as shortest"},
+		ids(routingv8.RankRoutesByLength(routes)),
+	)
+	assert.DeepEqual(
+		t,
+		[]string{"same duration as shortest", "fastest", "shortest"},
+		ids(routingv8.RankRoutes(routes, func(route *routingv8.Route) float64 {
+			return -float64(route.Summary().Length)
+		})),
+	)
+	assert.Equal(t, "fastest", routes[0].ID)
+	assert.DeepEqual(t, routingv8.Summary{Duration: 150, Length: 3500}, routes[0].Summary())
+}
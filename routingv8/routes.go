@@ -2,16 +2,33 @@ package routingv8
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
+
+	"go.einride.tech/here/internal/cache"
+	"go.einride.tech/here/internal/ratelimit"
 )
 
+// maxAlternatives is the maximum number of alternative routes allowed by the API.
+const maxAlternatives = 6
+
+// defaultMaxURLLength is the default length of a routes request URL above which the POST form of the request is
+// used, well below the limits of the API and common proxies.
+const defaultMaxURLLength = 8000
+
 // Routes returns all possible routes between origin and destination.
 // See https://developer.here.com/documentation/routing-api/dev_guide/topics/send-request.html#send-a-request
 // for details about other parameters.
+//
+// If the request URL would be longer than Client.MaxURLLength, e.g. due to many avoid areas, the POST form of the
+// request is used instead, with the avoid and exclude parameters in the body. Via waypoints are always sent in the
+// query.
 func (s *RoutingService) Routes(
 	ctx context.Context,
 	req *RoutesRequest,
@@ -27,47 +44,73 @@ func (s *RoutingService) Routes(
 	}
 
 	values := make(url.Values)
-	returns := make([]string, 0, len(req.Return))
-	if len(req.Return) > 0 {
-		for _, attribute := range req.Return {
-			returns = append(returns, string(attribute))
+	if err := addReturnQueryValues(values, req.Return, req.Spans); err != nil {
+		return nil, err
+	}
+	numTimes := 0
+	for _, isSet := range []bool{req.DepartureTime != "", !req.DepartAt.IsZero(), !req.ArriveAt.IsZero()} {
+		if isSet {
+			numTimes++
 		}
-	} else {
-		returns = []string{string(SummaryReturnAttribute)}
 	}
-	values.Add("return", strings.Join(returns, ","))
+	if numTimes > 1 {
+		return nil, errors.New("only one of DepartureTime, DepartAt and ArriveAt can be set")
+	}
 	if req.DepartureTime != "" {
 		values.Add("departureTime", req.DepartureTime)
 	}
+	if !req.DepartAt.IsZero() {
+		values.Add("departureTime", formatTime(req.DepartAt))
+	}
+	if !req.ArriveAt.IsZero() {
+		values.Add("arrivalTime", formatTime(req.ArriveAt))
+	}
 	values.Add("transportMode", tm)
 	values.Add("origin", fmt.Sprintf("%v,%v", req.Origin.Lat, req.Origin.Long))
 	values.Add("destination", fmt.Sprintf("%v,%v", req.Destination.Lat, req.Destination.Long))
+	if len(req.Via) > 0 && len(req.ViaWaypoints) > 0 {
+		return nil, errors.New("only one of Via and ViaWaypoints can be set")
+	}
 	for _, via := range req.Via {
 		values.Add("via", fmt.Sprintf("%v,%v", via.Lat, via.Long))
 	}
-	if len(req.Spans) > 0 {
-		if !returnContains(req.Return, PolylineReturnAttribute) {
-			return nil, errors.New("spans parameter also requires that the polyline option is set in the return parameter")
-		}
-		spanStrings := make([]string, 0, len(req.Spans))
-		for _, span := range req.Spans {
-			spanStrings = append(spanStrings, string(span))
+	for i := range req.ViaWaypoints {
+		via, err := req.ViaWaypoints[i].queryString()
+		if err != nil {
+			return nil, fmt.Errorf("via waypoint %d: %v", i, err)
 		}
-		values.Add("spans", strings.Join(spanStrings, ","))
+		values.Add("via", via)
 	}
-	if req.AvoidAreas != nil {
-		areas := make([]string, 0, len(req.AvoidAreas))
-		for _, area := range req.AvoidAreas {
-			a := area.String()
-			if a == invalid {
-				return nil, fmt.Errorf("invalid avoid area")
-			}
-			if a != unspecified {
-				areas = append(areas, a)
-			}
+	if req.Alternatives < 0 || req.Alternatives > maxAlternatives {
+		return nil, fmt.Errorf("invalid alternatives %d, must be between 0 and %d", req.Alternatives, maxAlternatives)
+	}
+	if req.Alternatives > 0 {
+		values.Add("alternatives", strconv.Itoa(req.Alternatives))
+	}
+	if req.Lang != "" {
+		values.Add("lang", req.Lang)
+	}
+	features := req.AvoidAreas
+	if req.Avoid != nil && len(req.Avoid.Features) > 0 {
+		features = append(append([]AreaFeature{}, req.AvoidAreas...), req.Avoid.Features...)
+	}
+	if features != nil {
+		areas, err := featureStrings(features)
+		if err != nil {
+			return nil, err
 		}
 		values.Add("avoid[features]", strings.Join(areas, ","))
 	}
+	if req.Avoid != nil {
+		if err := req.Avoid.addQueryValues(values); err != nil {
+			return nil, err
+		}
+	}
+	if req.Exclude != nil {
+		if err := req.Exclude.addQueryValues(values); err != nil {
+			return nil, err
+		}
+	}
 	rm := req.RoutingMode.String()
 	if rm == invalid {
 		return nil, fmt.Errorf("invalid routingmode")
@@ -82,17 +125,117 @@ func (s *RoutingService) Routes(
 	if trm != unspecified {
 		values.Add("trafficMode", trm)
 	}
-	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
+	if req.Currency != "" {
+		if !isAlpha3Code(req.Currency) {
+			return nil, fmt.Errorf("invalid currency %q, must be an ISO 4217 code", req.Currency)
+		}
+		values.Add("currency", req.Currency)
+	}
+	if req.Tolls != nil {
+		if err := req.Tolls.addQueryValues(values); err != nil {
+			return nil, err
+		}
+	}
+	if req.EV != nil {
+		if err := req.EV.addQueryValues(values); err != nil {
+			return nil, err
+		}
+	}
+	if req.Truck != nil {
+		if err := req.Truck.addQueryValues(values); err != nil {
+			return nil, err
+		}
+	}
+	method := http.MethodGet
+	var body []byte
+	u.RawQuery = values.Encode()
+	if len(u.String()) > s.Client.maxURLLength() && (req.Avoid != nil || features != nil || req.Exclude != nil) {
+		// Move the avoid and exclude parameters to the body, using the POST form of the request.
+		method = http.MethodPost
+		if body, err = req.postBody(values, features); err != nil {
+			return nil, err
+		}
+	}
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, method, values.Encode(), body)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create get request: %v", err)
+		return nil, fmt.Errorf("unable to create %s request: %v", strings.ToLower(method), err)
 	}
 	var resp RoutesResponse
-	if err := s.Client.Do(r, &resp); err != nil {
+	// The POST form only moves parameters to the body, so it is as safe to retry as the GET form.
+	send := func(r *http.Request, v interface{}) error {
+		return s.Client.do(r, v, true)
+	}
+	ttl := req.cacheTTL(s.CacheTTL, time.Now())
+	if err := cache.Do(r, s.Client.Cache, ttl, s.Client.Offline, &resp, send); err != nil {
 		return nil, err
 	}
+	if err := s.Client.checkCriticalNotices(&resp); err != nil {
+		return &resp, err
+	}
 	return &resp, nil
 }
 
+// postBody removes the avoid and exclude parameters from the query values and returns them as the body of the
+// POST form of the request. Via waypoints are kept in the query, since the body is only used for avoid and exclude.
+func (req *RoutesRequest) postBody(values url.Values, features []AreaFeature) ([]byte, error) {
+	body := struct {
+		Avoid   *Avoid   `json:"avoid,omitempty"`
+		Exclude *Exclude `json:"exclude,omitempty"`
+	}{
+		Exclude: req.Exclude,
+	}
+	if req.Avoid != nil || features != nil {
+		var avoid Avoid
+		if req.Avoid != nil {
+			avoid = *req.Avoid
+		}
+		avoid.Features = features
+		body.Avoid = &avoid
+	}
+	for key := range values {
+		if strings.HasPrefix(key, "avoid[") || strings.HasPrefix(key, "exclude[") {
+			values.Del(key)
+		}
+	}
+	return json.Marshal(body)
+}
+
+// addReturnQueryValues adds the return and spans parameters, which are shared by all requests returning routes.
+// Summary is returned if no attributes are requested.
+func addReturnQueryValues(values url.Values, attributes []ReturnAttribute, spans []SpanAttribute) error {
+	returns := make([]string, 0, len(attributes))
+	if len(attributes) > 0 {
+		for _, attribute := range attributes {
+			returns = append(returns, string(attribute))
+		}
+	} else {
+		returns = []string{string(SummaryReturnAttribute)}
+	}
+	values.Add("return", strings.Join(returns, ","))
+	if len(spans) > 0 {
+		if !returnContains(attributes, PolylineReturnAttribute) {
+			return errors.New("spans parameter also requires that the polyline option is set in the return parameter")
+		}
+		spanStrings := make([]string, 0, len(spans))
+		for _, span := range spans {
+			s := span.String()
+			if s == invalid {
+				return fmt.Errorf("invalid empty span attribute")
+			}
+			spanStrings = append(spanStrings, s)
+		}
+		values.Add("spans", strings.Join(spanStrings, ","))
+	}
+	if returnContains(attributes, InstructionsReturnAttribute) &&
+		!returnContains(attributes, ActionsReturnAttribute) &&
+		!returnContains(attributes, TurnByTurnActionsReturnAttribute) {
+		return errors.New(
+			"instructions option in the return parameter also requires that the actions or turnByTurnActions option is set",
+		)
+	}
+	return nil
+}
+
 func returnContains(requested []ReturnAttribute, needle ReturnAttribute) bool {
 	for _, attr := range requested {
 		if attr == needle {
@@ -101,3 +244,47 @@ func returnContains(requested []ReturnAttribute, needle ReturnAttribute) bool {
 	}
 	return false
 }
+
+// addQueryValues adds the truck as vehicle parameters, which replace the deprecated truck parameters.
+// Unset values are left out to use the defaults of the API.
+func (t *Truck) addQueryValues(values url.Values) error {
+	if len(t.ShippedHazardousGoods) > 0 {
+		goods := make([]string, 0, len(t.ShippedHazardousGoods))
+		for _, g := range t.ShippedHazardousGoods {
+			s := g.String()
+			if s == invalid {
+				return fmt.Errorf("invalid shipped hazardous goods")
+			}
+			if s != unspecified {
+				goods = append(goods, s)
+			}
+		}
+		if len(goods) > 0 {
+			values.Add("vehicle[shippedHazardousGoods]", strings.Join(goods, ","))
+		}
+	}
+	for _, param := range []struct {
+		key   string
+		value int
+	}{
+		{key: "vehicle[grossWeight]", value: t.GrossWeight},
+		{key: "vehicle[weightPerAxle]", value: t.WeightPerAxle},
+		{key: "vehicle[height]", value: t.Height},
+		{key: "vehicle[width]", value: t.Width},
+		{key: "vehicle[length]", value: t.Length},
+		{key: "vehicle[axleCount]", value: t.AxleCount},
+		{key: "vehicle[trailerCount]", value: t.TrailerCount},
+	} {
+		if param.value != 0 {
+			values.Add(param.key, strconv.Itoa(param.value))
+		}
+	}
+	tc := t.TunnelCategory.String()
+	if tc == invalid {
+		return fmt.Errorf("invalid tunnel category")
+	}
+	if t.TunnelCategory != TunnelCategoryUnspecified {
+		values.Add("vehicle[tunnelCategory]", tc)
+	}
+	return nil
+}
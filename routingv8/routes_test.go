@@ -5,15 +5,22 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"go.einride.tech/here/routingv8"
 	"gotest.tools/v3/assert"
 )
 
 type RoutesMock struct {
+	requestPath     string
 	requestRawQuery string
 	responseStatus  int
 	responseBody    routingv8.RoutesResponse
@@ -21,6 +28,7 @@ type RoutesMock struct {
 }
 
 func (c *RoutesMock) Do(req *http.Request) (*http.Response, error) {
+	c.requestPath = req.URL.EscapedPath()
 	c.requestRawQuery = req.URL.RawQuery
 	headers := http.Header{}
 	headers.Add("Content-Type", "application/json")
@@ -118,6 +126,7 @@ func TestRoutingervice_Routes_QueryParams(t *testing.T) {
 		Lat:  59.337492,
 		Long: 18.063672,
 	}
+	course := 90
 
 	for _, tt := range []struct {
 		name     string
@@ -183,6 +192,64 @@ func TestRoutingervice_Routes_QueryParams(t *testing.T) {
 			expected: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767" +
 				"&return=summary%2Cpolyline&spans=names%2CmaxSpeed&transportMode=car",
 		},
+		{
+			name: "with all span attributes",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				Return: []routingv8.ReturnAttribute{
+					routingv8.PolylineReturnAttribute,
+				},
+				Spans: []routingv8.SpanAttribute{
+					routingv8.SpanAttributeSpeedLimit,
+					routingv8.SpanAttributeFunctionalClass,
+					routingv8.SpanAttributeSegmentID,
+					routingv8.SpanAttributeCountryCode,
+					routingv8.SpanAttributeStateCode,
+					routingv8.SpanAttributeTruckAttributes,
+					routingv8.SpanAttributeCarAttributes,
+					routingv8.SpanAttributeStreetAttributes,
+					routingv8.SpanAttributeDuration,
+					routingv8.SpanAttributeLength,
+					routingv8.SpanAttributeDynamicSpeedInfo,
+					routingv8.SpanAttributeTollSystems,
+					routingv8.SpanAttributeRouteNumbers,
+					routingv8.SpanAttributeNotices,
+				},
+			},
+			expected: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767&return=polyline" +
+				"&spans=speedLimit%2CfunctionalClass%2CsegmentId%2CcountryCode%2CstateCode%2CtruckAttributes" +
+				"%2CcarAttributes%2CstreetAttributes%2Cduration%2Clength%2CdynamicSpeedInfo%2CtollSystems" +
+				"%2CrouteNumbers%2Cnotices&transportMode=truck",
+		},
+		{
+			name: "with invalid span attribute",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Return: []routingv8.ReturnAttribute{
+					routingv8.PolylineReturnAttribute,
+				},
+				Spans: []routingv8.SpanAttribute{""},
+			},
+			errStr: "invalid empty span attribute",
+		},
+		{
+			name: "with span attribute without constant",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Return: []routingv8.ReturnAttribute{
+					routingv8.PolylineReturnAttribute,
+				},
+				Spans: []routingv8.SpanAttribute{"segmentRef", "incidents"},
+			},
+			expected: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767" +
+				"&return=polyline&spans=segmentRef%2Cincidents&transportMode=car",
+		},
 		{
 			name: "with spans without wanted polyline returned",
 			request: &routingv8.RoutesRequest{
@@ -220,6 +287,50 @@ func TestRoutingervice_Routes_QueryParams(t *testing.T) {
 				"&return=summary&transportMode=car" +
 				"&via=57.695538%2C11.992594&via=59.32341%2C18.096137",
 		},
+		{
+			name: "with via waypoint options",
+			request: &routingv8.RoutesRequest{
+				Origin:      origin,
+				Destination: destination,
+				ViaWaypoints: []routingv8.Waypoint{
+					{
+						Location:          routingv8.GeoWaypoint{Lat: 57.695538, Long: 11.992594},
+						StopDuration:      10 * time.Minute,
+						Course:            &course,
+						SideOfStreetHint:  &routingv8.GeoWaypoint{Lat: 57.6956, Long: 11.9926},
+						MatchSideOfStreet: routingv8.MatchSideOfStreetAlways,
+						Radius:            50,
+						NameHint:          "Lindholmsallén",
+					},
+					{
+						Location:    routingv8.GeoWaypoint{Lat: 59.323410, Long: 18.096137},
+						PassThrough: true,
+					},
+				},
+				TransportMode: routingv8.TransportModeCar,
+			},
+			expected: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767" +
+				"&return=summary&transportMode=car" +
+				"&via=57.695538%2C11.992594%3Bcourse%3D90%3BsideOfStreetHint%3D57.6956%2C11.9926" +
+				"%3BmatchSideOfStreet%3Dalways%3Bradius%3D50%3BnameHint%3DLindholmsall%C3%A9n%21stopDuration%3D600" +
+				"&via=59.32341%2C18.096137%21passThrough%3Dtrue",
+		},
+		{
+			name: "with pass through via waypoint with stop duration",
+			request: &routingv8.RoutesRequest{
+				Origin:      origin,
+				Destination: destination,
+				ViaWaypoints: []routingv8.Waypoint{
+					{
+						Location:     routingv8.GeoWaypoint{Lat: 57.695538, Long: 11.992594},
+						StopDuration: 10 * time.Minute,
+						PassThrough:  true,
+					},
+				},
+				TransportMode: routingv8.TransportModeCar,
+			},
+			errStr: "via waypoint 0: a pass through waypoint can not have a stop duration",
+		},
 		{
 			name: "with routingmode",
 			request: &routingv8.RoutesRequest{
@@ -242,6 +353,353 @@ func TestRoutingervice_Routes_QueryParams(t *testing.T) {
 			expected: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767" +
 				"&return=summary&trafficMode=disabled&transportMode=car",
 		},
+		{
+			name: "with actions and instructions",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Return: []routingv8.ReturnAttribute{
+					routingv8.ActionsReturnAttribute,
+					routingv8.InstructionsReturnAttribute,
+				},
+				Lang: "sv-SE",
+			},
+			expected: "destination=59.337492%2C18.063672&lang=sv-SE&origin=57.707752%2C11.949767" +
+				"&return=actions%2Cinstructions&transportMode=car",
+		},
+		{
+			name: "with instructions without actions",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Return: []routingv8.ReturnAttribute{
+					routingv8.InstructionsReturnAttribute,
+				},
+			},
+			errStr: "also requires that the actions or turnByTurnActions option is set",
+		},
+		{
+			name: "with alternatives",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Alternatives:  3,
+			},
+			expected: "alternatives=3&destination=59.337492%2C18.063672&origin=57.707752%2C11.949767" +
+				"&return=summary&transportMode=car",
+		},
+		{
+			name: "with too many alternatives",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Alternatives:  7,
+			},
+			errStr: "invalid alternatives 7",
+		},
+		{
+			name: "with avoid and exclude",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				AvoidAreas:    []routingv8.AreaFeature{routingv8.AreaFeatureFerry},
+				Avoid: &routingv8.Avoid{
+					Features: []routingv8.AreaFeature{routingv8.AreaFeatureTollRoad},
+					Areas: []routingv8.AvoidArea{
+						{
+							Type:             routingv8.AvoidAreaTypeBoundingBox,
+							BoundingBoxNorth: 57.8,
+							BoundingBoxEast:  12.1,
+							BoundingBoxSouth: 57.6,
+							BoundingBoxWest:  11.8,
+						},
+						{
+							Type: routingv8.AvoidAreaTypePolygon,
+							PolygonOuter: []routingv8.GeoWaypoint{
+								{Lat: 58.1, Long: 14.1},
+								{Lat: 58.2, Long: 14.2},
+								{Lat: 58.1, Long: 14.3},
+							},
+						},
+						{
+							Type: routingv8.AvoidAreaTypeCorridor,
+							CorridorPoints: []routingv8.GeoWaypoint{
+								{Lat: 59.1, Long: 17.1},
+								{Lat: 59.2, Long: 17.2},
+							},
+							CorridorRadius: 500,
+						},
+					},
+					Segments:       []string{"here:cm:segment:76771992#+", "here:cm:segment:76771993"},
+					ZoneCategories: []routingv8.ZoneCategory{routingv8.ZoneCategoryEnvironmental},
+				},
+				Exclude: &routingv8.Exclude{Countries: []string{"NOR", "FIN"}},
+			},
+			expected: "avoid%5Bareas%5D=bbox%3A11.8%2C57.6%2C12.1%2C57.8" +
+				"%7Cpolygon%3A58.1%2C14.1%3B58.2%2C14.2%3B58.1%2C14.3" +
+				"%7Ccorridor%3ABFg_2oLg7roDgxTgxT%3Br%3D500" +
+				"&avoid%5Bfeatures%5D=ferry%2CtollRoad" +
+				"&avoid%5Bsegments%5D=here%3Acm%3Asegment%3A76771992%23%2B%2Chere%3Acm%3Asegment%3A76771993" +
+				"&avoid%5BzoneCategories%5D=environmental" +
+				"&destination=59.337492%2C18.063672&exclude%5Bcountries%5D=NOR%2CFIN" +
+				"&origin=57.707752%2C11.949767&return=summary&transportMode=car",
+		},
+		{
+			name: "with avoid corridor",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Avoid: &routingv8.Avoid{
+					Areas: []routingv8.AvoidArea{
+						{
+							Type: routingv8.AvoidAreaTypeCorridor,
+							CorridorPoints: []routingv8.GeoWaypoint{
+								{Lat: 59.1, Long: 17.1},
+								{Lat: 59.2, Long: 17.2},
+							},
+							CorridorRadius: 500,
+						},
+					},
+				},
+			},
+			// The corridor is encoded as a Flexible Polyline, as in the POST body.
+			expected: "avoid%5Bareas%5D=corridor%3ABFg_2oLg7roDgxTgxT%3Br%3D500" +
+				"&destination=59.337492%2C18.063672" +
+				"&origin=57.707752%2C11.949767&return=summary&transportMode=car",
+		},
+		{
+			name: "with invalid avoid area",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Avoid: &routingv8.Avoid{
+					Areas: []routingv8.AvoidArea{
+						{
+							Type:         routingv8.AvoidAreaTypePolygon,
+							PolygonOuter: []routingv8.GeoWaypoint{{Lat: 58.1, Long: 14.1}},
+						},
+					},
+				},
+			},
+			errStr: "invalid polygon",
+		},
+		{
+			name: "with invalid exclude country",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Exclude:       &routingv8.Exclude{Countries: []string{"SE"}},
+			},
+			errStr: "invalid exclude country",
+		},
+		{
+			name: "with departure time",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				DepartAt:      time.Date(2021, 11, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600)),
+			},
+			expected: "departureTime=2021-11-01T10%3A00%3A00%2B01%3A00&destination=59.337492%2C18.063672" +
+				"&origin=57.707752%2C11.949767&return=summary&transportMode=car",
+		},
+		{
+			name: "with arrival time",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				ArriveAt:      time.Date(2021, 11, 1, 9, 0, 0, 0, time.UTC),
+			},
+			expected: "arrivalTime=2021-11-01T09%3A00%3A00Z&destination=59.337492%2C18.063672" +
+				"&origin=57.707752%2C11.949767&return=summary&transportMode=car",
+		},
+		{
+			name: "with both departure and arrival time",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				DepartureTime: routingv8.DepartureTimeAny,
+				ArriveAt:      time.Date(2021, 11, 1, 9, 0, 0, 0, time.UTC),
+			},
+			errStr: "only one of DepartureTime, DepartAt and ArriveAt can be set",
+		},
+		{
+			name: "with tolls",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				Return:        []routingv8.ReturnAttribute{routingv8.SummaryReturnAttribute, routingv8.TollsReturnAttribute},
+				Currency:      "SEK",
+				Tolls: &routingv8.TollOptions{
+					EmissionType: routingv8.EmissionTypeEuro6,
+					CO2Class:     2,
+					Transponders: true,
+				},
+			},
+			expected: "currency=SEK&destination=59.337492%2C18.063672&origin=57.707752%2C11.949767" +
+				"&return=summary%2Ctolls&tolls%5BemissionType%5D=euro6%3Bco2class%3D2&tolls%5Btransponders%5D=all" +
+				"&transportMode=truck",
+		},
+		{
+			name: "with invalid currency",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Currency:      "kr",
+			},
+			errStr: "invalid currency",
+		},
+		{
+			name: "with ev",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				EV: &routingv8.EV{
+					FreeFlowSpeedTable: []routingv8.ConsumptionPoint{
+						{Speed: 0, Consumption: 1.2},
+						{Speed: 80, Consumption: 1.4},
+					},
+					AuxiliaryConsumption: 2.5,
+					InitialCharge:        300,
+					MaxCharge:            400,
+					ChargingCurve: []routingv8.ChargingCurvePoint{
+						{Charge: 0, Power: 350},
+						{Charge: 320, Power: 150},
+					},
+					MaxChargeAfterChargingStation: 320,
+					MinChargeAtChargingStation:    40,
+					MinChargeAtDestination:        60,
+					ChargingSetupDuration:         5 * time.Minute,
+					ConnectorTypes: []routingv8.ConnectorType{
+						routingv8.ConnectorTypeIEC62196Type2Combo,
+					},
+					MakeReachable: true,
+				},
+			},
+			expected: "destination=59.337492%2C18.063672" +
+				"&ev%5BauxiliaryConsumption%5D=2.5&ev%5BchargingCurve%5D=0%2C350%2C320%2C150" +
+				"&ev%5BchargingSetupDuration%5D=300&ev%5BconnectorTypes%5D=iec62196Type2Combo" +
+				"&ev%5BfreeFlowSpeedTable%5D=0%2C1.2%2C80%2C1.4&ev%5BinitialCharge%5D=300&ev%5BmakeReachable%5D=true" +
+				"&ev%5BmaxChargeAfterChargingStation%5D=320&ev%5BmaxCharge%5D=400" +
+				"&ev%5BminChargeAtChargingStation%5D=40&ev%5BminChargeAtDestination%5D=60" +
+				"&origin=57.707752%2C11.949767&return=summary&transportMode=truck",
+		},
+		{
+			name: "with ev make reachable with zero min charges",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				EV: &routingv8.EV{
+					FreeFlowSpeedTable:            []routingv8.ConsumptionPoint{{Speed: 0, Consumption: 0.2}},
+					MaxCharge:                     80,
+					ChargingCurve:                 []routingv8.ChargingCurvePoint{{Charge: 0, Power: 50}},
+					MaxChargeAfterChargingStation: 64,
+					ConnectorTypes:                []routingv8.ConnectorType{routingv8.ConnectorTypeChademo},
+					MakeReachable:                 true,
+				},
+			},
+			expected: "destination=59.337492%2C18.063672" +
+				"&ev%5BchargingCurve%5D=0%2C50&ev%5BconnectorTypes%5D=chademo" +
+				"&ev%5BfreeFlowSpeedTable%5D=0%2C0.2&ev%5BinitialCharge%5D=0&ev%5BmakeReachable%5D=true" +
+				"&ev%5BmaxChargeAfterChargingStation%5D=64&ev%5BmaxCharge%5D=80" +
+				"&ev%5BminChargeAtChargingStation%5D=0&ev%5BminChargeAtDestination%5D=0" +
+				"&origin=57.707752%2C11.949767&return=summary&transportMode=car",
+		},
+		{
+			name: "with ev make reachable with min charge above max charge",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				EV: &routingv8.EV{
+					FreeFlowSpeedTable:            []routingv8.ConsumptionPoint{{Speed: 0, Consumption: 0.2}},
+					MaxCharge:                     80,
+					ChargingCurve:                 []routingv8.ChargingCurvePoint{{Charge: 0, Power: 50}},
+					MaxChargeAfterChargingStation: 64,
+					MinChargeAtDestination:        90,
+					ConnectorTypes:                []routingv8.ConnectorType{routingv8.ConnectorTypeChademo},
+					MakeReachable:                 true,
+				},
+			},
+			errStr: "invalid ev min charge 90",
+		},
+		{
+			name: "with ev without free flow speed table",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				EV:            &routingv8.EV{InitialCharge: 40, MaxCharge: 80},
+			},
+			errStr: "ev free flow speed table is required",
+		},
+		{
+			name: "with ev make reachable without charging curve",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				EV: &routingv8.EV{
+					FreeFlowSpeedTable: []routingv8.ConsumptionPoint{{Speed: 0, Consumption: 0.2}},
+					InitialCharge:      40,
+					MaxCharge:          80,
+					MakeReachable:      true,
+				},
+			},
+			errStr: "ev make reachable requires",
+		},
+		{
+			name: "with truck",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				Truck: &routingv8.Truck{
+					ShippedHazardousGoods: routingv8.ShippedHazardousGoodsList{
+						routingv8.ShippedHazardousGoodsExplosive,
+						routingv8.ShippedHazardousGoodsFlammable,
+					},
+					GrossWeight:    40000,
+					WeightPerAxle:  10000,
+					Height:         400,
+					Length:         1875,
+					TunnelCategory: routingv8.TunnelCategoryC,
+					AxleCount:      5,
+					TrailerCount:   1,
+				},
+			},
+			expected: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767" +
+				"&return=summary&transportMode=truck" +
+				"&vehicle%5BaxleCount%5D=5&vehicle%5BgrossWeight%5D=40000&vehicle%5Bheight%5D=400" +
+				"&vehicle%5Blength%5D=1875&vehicle%5BshippedHazardousGoods%5D=explosive%2Cflammable" +
+				"&vehicle%5BtrailerCount%5D=1&vehicle%5BtunnelCategory%5D=C&vehicle%5BweightPerAxle%5D=10000",
+		},
+		{
+			name: "with invalid truck",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				Truck: &routingv8.Truck{
+					TunnelCategory: 42,
+				},
+			},
+			errStr: "invalid tunnel category",
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
@@ -291,3 +749,137 @@ func TestRoutingervice_Routes_Error(t *testing.T) {
 	assert.DeepEqual(t, responseError.Response, &exp)
 	assert.Check(t, responseError.HTTPBody != "")
 }
+
+func TestRoutingService_Routes_Post(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	origin := routingv8.GeoWaypoint{Lat: 57.707752, Long: 11.949767}
+	destination := routingv8.GeoWaypoint{Lat: 59.337492, Long: 18.063672}
+	manyVias := make([]routingv8.GeoWaypoint, 0, 400)
+	for i := 0; i < cap(manyVias); i++ {
+		manyVias = append(manyVias, routingv8.GeoWaypoint{Lat: 58 + float64(i)/1000, Long: 15.123456})
+	}
+	type recordedRequest struct {
+		method   string
+		rawQuery string
+		body     string
+	}
+	for _, tt := range []struct {
+		name         string
+		maxURLLength int
+		request      *routingv8.RoutesRequest
+		expected     recordedRequest
+	}{
+		{
+			name: "short url",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				Via:           manyVias[:2],
+				TransportMode: routingv8.TransportModeCar,
+			},
+			expected: recordedRequest{
+				method: http.MethodGet,
+				rawQuery: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767&return=summary" +
+					"&transportMode=car&via=58%2C15.123456&via=58.001%2C15.123456",
+			},
+		},
+		{
+			name: "many vias",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				Via:           manyVias,
+				TransportMode: routingv8.TransportModeCar,
+			},
+			// Via waypoints are always sent in the query, so there is nothing to move to the body.
+			expected: recordedRequest{
+				method: http.MethodGet,
+				rawQuery: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767&return=summary" +
+					"&transportMode=car" + viaQuery(manyVias),
+			},
+		},
+		{
+			name:         "many vias and avoid features",
+			maxURLLength: 200,
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				Via:           manyVias[:2],
+				TransportMode: routingv8.TransportModeCar,
+				AvoidAreas:    []routingv8.AreaFeature{routingv8.AreaFeatureFerry, routingv8.AreaFeatureTunnel},
+			},
+			expected: recordedRequest{
+				method: http.MethodPost,
+				rawQuery: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767&return=summary" +
+					"&transportMode=car" + viaQuery(manyVias[:2]),
+				body: `{"avoid":{"features":["ferry","tunnel"]}}`,
+			},
+		},
+		{
+			name:         "avoid and exclude with max url length",
+			maxURLLength: 200,
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				AvoidAreas:    []routingv8.AreaFeature{routingv8.AreaFeatureFerry},
+				Avoid: &routingv8.Avoid{
+					Features: []routingv8.AreaFeature{routingv8.AreaFeatureTollRoad},
+					Areas: []routingv8.AvoidArea{
+						{
+							Type:             routingv8.AvoidAreaTypeBoundingBox,
+							BoundingBoxNorth: 58.1,
+							BoundingBoxEast:  12.1,
+							BoundingBoxSouth: 58,
+							BoundingBoxWest:  12,
+						},
+					},
+				},
+				Exclude: &routingv8.Exclude{Countries: []string{"NOR"}},
+			},
+			expected: recordedRequest{
+				method: http.MethodPost,
+				rawQuery: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767&return=summary" +
+					"&transportMode=truck",
+				// The features of AvoidAreas and Avoid are merged, as in the avoid[features] parameter of the GET form.
+				body: `{"avoid":{"features":["ferry","tollRoad"],` +
+					`"areas":[{"type":"boundingBox","north":58.1,"south":58,"west":12,"east":12.1}]},` +
+					`"exclude":{"countries":["NOR"]}}`,
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got recordedRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NilError(t, err)
+				got = recordedRequest{method: r.Method, rawQuery: r.URL.RawQuery, body: string(body)}
+				assert.Equal(t, "/v8/routes", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"routes":[{"id":"1"}]}`))
+			}))
+			defer server.Close()
+			routingClient := routingv8.NewClient(server.Client())
+			routingClient.MaxURLLength = tt.maxURLLength
+			u, err := url.Parse(server.URL + "/v8/")
+			assert.NilError(t, err)
+			routingClient.Routing.URL = u
+			resp, err := routingClient.Routing.Routes(ctx, tt.request)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, []routingv8.Route{{ID: "1"}}, resp.Routes)
+			assert.DeepEqual(t, tt.expected, got, cmp.AllowUnexported(recordedRequest{}))
+		})
+	}
+}
+
+// viaQuery returns the via parameters of the waypoints, as encoded in the query of a request.
+func viaQuery(vias []routingv8.GeoWaypoint) string {
+	var b strings.Builder
+	for _, via := range vias {
+		b.WriteString("&via=" + url.QueryEscape(fmt.Sprintf("%v,%v", via.Lat, via.Long)))
+	}
+	return b.String()
+}
@@ -0,0 +1,201 @@
+package routingv8
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// TollOptions defines the vehicle properties used to calculate toll costs.
+// The axle count and weights of the vehicle are taken from RoutesRequest.Truck.
+type TollOptions struct {
+	// EmissionType of the vehicle.
+	EmissionType EmissionType
+	// CO2Class of the vehicle, between 1 and 5. Requires EmissionType.
+	CO2Class int
+	// Transponders makes the route assume that the vehicle has all transponders, e.g. for toll roads that can
+	// only be paid by transponder.
+	Transponders bool
+	// Vignettes makes the route assume that the vehicle has all vignettes.
+	Vignettes bool
+}
+
+type EmissionType int
+
+const (
+	EmissionTypeUnspecified EmissionType = iota
+	EmissionTypeEuro1
+	EmissionTypeEuro2
+	EmissionTypeEuro3
+	EmissionTypeEuro4
+	EmissionTypeEuro5
+	EmissionTypeEuro6
+	EmissionTypeEuroEEV
+)
+
+func (e *EmissionType) String() string {
+	switch *e {
+	case EmissionTypeUnspecified:
+		return unspecified
+	case EmissionTypeEuro1:
+		return "euro1"
+	case EmissionTypeEuro2:
+		return "euro2"
+	case EmissionTypeEuro3:
+		return "euro3"
+	case EmissionTypeEuro4:
+		return "euro4"
+	case EmissionTypeEuro5:
+		return "euro5"
+	case EmissionTypeEuro6:
+		return "euro6"
+	case EmissionTypeEuroEEV:
+		return "euroEev"
+	default:
+		return invalid
+	}
+}
+
+func (t *TollOptions) addQueryValues(values url.Values) error {
+	et := t.EmissionType.String()
+	if et == invalid {
+		return fmt.Errorf("invalid emission type")
+	}
+	if t.CO2Class != 0 && et == unspecified {
+		return fmt.Errorf("co2 class requires an emission type")
+	}
+	if t.CO2Class < 0 || t.CO2Class > 5 {
+		return fmt.Errorf("invalid co2 class %d, must be between 1 and 5", t.CO2Class)
+	}
+	if et != unspecified {
+		if t.CO2Class != 0 {
+			et = fmt.Sprintf("%s;co2class=%d", et, t.CO2Class)
+		}
+		values.Add("tolls[emissionType]", et)
+	}
+	if t.Transponders {
+		values.Add("tolls[transponders]", "all")
+	}
+	if t.Vignettes {
+		values.Add("tolls[vignettes]", "all")
+	}
+	return nil
+}
+
+// Toll is a toll to pay along a section.
+type Toll struct {
+	// CountryCode of the toll in ISO 3166-1 alpha-3 format.
+	CountryCode string `json:"countryCode"`
+	// TollSystem is the name of the toll system collecting the toll.
+	TollSystem string `json:"tollSystem"`
+	// TollSystems are indices into Section.TollSystems of the toll systems collecting the toll.
+	TollSystems []int `json:"tollSystems"`
+	// Fares that can be paid for the toll, e.g. for different payment methods.
+	Fares []TollFare `json:"fares"`
+	// TollCollectionLocations where the toll is collected.
+	TollCollectionLocations []TollCollectionLocation `json:"tollCollectionLocations"`
+}
+
+// TollFare is a fare that can be paid for a toll.
+type TollFare struct {
+	// ID of the fare.
+	ID string `json:"id"`
+	// Name of the fare.
+	Name string `json:"name"`
+	// Price of the fare in the local currency.
+	Price Price `json:"price"`
+	// ConvertedPrice of the fare in the currency requested with RoutesRequest.Currency.
+	ConvertedPrice *Price `json:"convertedPrice"`
+	// Reason for the fare, e.g. "toll".
+	Reason string `json:"reason"`
+	// PaymentMethods accepted for the fare.
+	PaymentMethods []PaymentMethod `json:"paymentMethods"`
+}
+
+// Price of a fare.
+type Price struct {
+	// Type of the price, "value" or "range".
+	Type string `json:"type"`
+	// Currency in ISO 4217 format.
+	Currency string `json:"currency"`
+	// Value of the price. Only set for prices of type "value".
+	Value float64 `json:"value"`
+	// MinValue of the price. Only set for prices of type "range".
+	MinValue float64 `json:"minValue"`
+	// MaxValue of the price. Only set for prices of type "range".
+	MaxValue float64 `json:"maxValue"`
+	// Estimated is true if the price is an estimate.
+	Estimated bool `json:"estimated"`
+}
+
+// amount returns the value of the price, or the maximum value for a range.
+func (p *Price) amount() float64 {
+	if p.Type == "range" {
+		return p.MaxValue
+	}
+	return p.Value
+}
+
+// PaymentMethod is a method to pay a toll fare.
+type PaymentMethod string
+
+const (
+	PaymentMethodCash             PaymentMethod = "cash"
+	PaymentMethodBankCard         PaymentMethod = "bankCard"
+	PaymentMethodCreditCard       PaymentMethod = "creditCard"
+	PaymentMethodPassSubscription PaymentMethod = "passSubscription"
+	PaymentMethodTransponder      PaymentMethod = "transponder"
+	PaymentMethodTravelCard       PaymentMethod = "travelCard"
+	PaymentMethodVideoToll        PaymentMethod = "videoToll"
+)
+
+// TollCollectionLocation is a location where a toll is collected, e.g. a toll booth.
+type TollCollectionLocation struct {
+	// Name of the location.
+	Name string `json:"name"`
+	// Location in lat and long.
+	Location GeoWaypoint `json:"location"`
+}
+
+// TollSystem collecting tolls along a section.
+type TollSystem struct {
+	// Name of the toll system.
+	Name string `json:"name"`
+	// Language of the name in BCP47 format.
+	Language string `json:"language"`
+}
+
+// TollCosts returns the cost of the tolls along the route, summed per currency.
+// For each toll the cheapest fare is used. Fares are compared in the converted currency if all fares of the toll
+// have a converted price, and in the local currency otherwise. Price ranges are counted with their maximum value.
+func (r *Route) TollCosts() map[string]float64 {
+	costs := make(map[string]float64)
+	for _, section := range r.Sections {
+		for _, toll := range section.Tolls {
+			if cheapest := toll.cheapestPrice(); cheapest != nil {
+				costs[cheapest.Currency] += cheapest.amount()
+			}
+		}
+	}
+	return costs
+}
+
+// cheapestPrice returns the price of the cheapest fare of the toll, or nil if it has no fares.
+func (t *Toll) cheapestPrice() *Price {
+	converted := len(t.Fares) > 0
+	for i := range t.Fares {
+		if t.Fares[i].ConvertedPrice == nil {
+			converted = false
+		}
+	}
+	var cheapest *Price
+	for i := range t.Fares {
+		price := &t.Fares[i].Price
+		if converted {
+			price = t.Fares[i].ConvertedPrice
+		}
+		if cheapest == nil || price.amount() < cheapest.amount() {
+			cheapest = price
+		}
+	}
+	return cheapest
+}
@@ -0,0 +1,67 @@
+package routingv8_test
+
+import (
+	"testing"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestRoute_TollCosts(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		fares    []routingv8.TollFare
+		expected map[string]float64
+	}{
+		{
+			name: "converted",
+			fares: []routingv8.TollFare{
+				{
+					Price:          routingv8.Price{Type: "value", Currency: "DKK", Value: 960},
+					ConvertedPrice: &routingv8.Price{Type: "value", Currency: "SEK", Value: 1470},
+				},
+				{
+					Price:          routingv8.Price{Type: "value", Currency: "DKK", Value: 768},
+					ConvertedPrice: &routingv8.Price{Type: "value", Currency: "SEK", Value: 1176},
+				},
+			},
+			expected: map[string]float64{"SEK": 1176},
+		},
+		{
+			name: "mixed currencies",
+			fares: []routingv8.TollFare{
+				{
+					Price:          routingv8.Price{Type: "value", Currency: "DKK", Value: 960},
+					ConvertedPrice: &routingv8.Price{Type: "value", Currency: "SEK", Value: 1470},
+				},
+				{
+					Price: routingv8.Price{Type: "value", Currency: "DKK", Value: 1000},
+				},
+			},
+			// Not all fares are converted, so the fares are compared in the local currency.
+			expected: map[string]float64{"DKK": 960},
+		},
+		{
+			name: "range",
+			fares: []routingv8.TollFare{
+				{Price: routingv8.Price{Type: "range", Currency: "EUR", MinValue: 10, MaxValue: 30}},
+				{Price: routingv8.Price{Type: "value", Currency: "EUR", Value: 20}},
+			},
+			expected: map[string]float64{"EUR": 20},
+		},
+		{
+			name:     "no fares",
+			expected: map[string]float64{},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			route := routingv8.Route{
+				Sections: []routingv8.Section{{Tolls: []routingv8.Toll{{Fares: tt.fares}}}},
+			}
+			assert.DeepEqual(t, tt.expected, route.TollCosts())
+		})
+	}
+}
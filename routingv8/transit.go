@@ -0,0 +1,201 @@
+package routingv8
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.einride.tech/here/internal/ratelimit"
+)
+
+// TransitRoutesRequest is a request for public transit routes between origin and destination.
+type TransitRoutesRequest struct {
+	Origin      GeoWaypoint
+	Destination GeoWaypoint
+	// DepartAt is the time of departure. If zero and ArriveAt is not set, the current time is used.
+	// Mutually exclusive with ArriveAt.
+	DepartAt time.Time
+	// ArriveAt is the time of arrival. Mutually exclusive with DepartAt.
+	ArriveAt time.Time
+	// Modes of transit to use. If empty, all modes are used. Mutually exclusive with ExcludeModes.
+	Modes []TransitMode
+	// ExcludeModes are the modes of transit not to use. Mutually exclusive with Modes.
+	ExcludeModes []TransitMode
+	// MaxChanges is the maximum number of changes between transit sections. If nil, the API default is used.
+	MaxChanges *int
+	// Alternatives is the number of alternative routes to return, between 0 and 6.
+	Alternatives int
+	// Lang is the language of names and instructions, as a BCP 47 language tag, e.g. "en-US".
+	Lang string
+	// Return attributes, e.g. PolylineReturnAttribute or IntermediateReturnAttribute.
+	// If not specified defaults to TravelSummaryReturnAttribute.
+	Return []ReturnAttribute
+	// PedestrianSpeed in meters per second, used for walking sections.
+	PedestrianSpeed float64
+	// PedestrianMaxDistance in meters of a single walking section.
+	PedestrianMaxDistance int
+}
+
+type TransitMode int
+
+const (
+	TransitModeUnspecified TransitMode = iota
+	TransitModeHighSpeedTrain
+	TransitModeIntercityTrain
+	TransitModeInterRegionalTrain
+	TransitModeRegionalTrain
+	TransitModeCityTrain
+	TransitModeBus
+	TransitModeFerry
+	TransitModeSubway
+	TransitModeLightRail
+	TransitModePrivateBus
+	TransitModeInclined
+	TransitModeAerial
+	TransitModeBusRapid
+	TransitModeMonorail
+	TransitModeFlight
+)
+
+func (t *TransitMode) String() string {
+	switch *t {
+	case TransitModeUnspecified:
+		return unspecified
+	case TransitModeHighSpeedTrain:
+		return "highSpeedTrain"
+	case TransitModeIntercityTrain:
+		return "intercityTrain"
+	case TransitModeInterRegionalTrain:
+		return "interRegionalTrain"
+	case TransitModeRegionalTrain:
+		return "regionalTrain"
+	case TransitModeCityTrain:
+		return "cityTrain"
+	case TransitModeBus:
+		return "bus"
+	case TransitModeFerry:
+		return "ferry"
+	case TransitModeSubway:
+		return "subway"
+	case TransitModeLightRail:
+		return "lightRail"
+	case TransitModePrivateBus:
+		return "privateBus"
+	case TransitModeInclined:
+		return "inclined"
+	case TransitModeAerial:
+		return "aerial"
+	case TransitModeBusRapid:
+		return "busRapid"
+	case TransitModeMonorail:
+		return "monorail"
+	case TransitModeFlight:
+		return "flight"
+	default:
+		return invalid
+	}
+}
+
+// Routes returns public transit routes between origin and destination.
+// Transit sections have the Transport, Agency and IntermediateStops of the line set, and their departure and
+// arrival places are the stops of the line.
+// See https://developer.here.com/documentation/public-transit/dev_guide/routing/index.html
+// for details about other parameters.
+func (s *TransitService) Routes(
+	ctx context.Context,
+	req *TransitRoutesRequest,
+) (_ *RoutesResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("transit routes: %v", err)
+		}
+	}()
+	values, err := req.queryValues()
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.URL.Parse("routes")
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.Client.NewRequest(ratelimit.NewContext(ctx, s.RateLimiter), u, http.MethodGet, values.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp RoutesResponse
+	if err := s.Client.Do(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (r *TransitRoutesRequest) queryValues() (url.Values, error) {
+	values := make(url.Values)
+	values.Add("origin", fmt.Sprintf("%v,%v", r.Origin.Lat, r.Origin.Long))
+	values.Add("destination", fmt.Sprintf("%v,%v", r.Destination.Lat, r.Destination.Long))
+	if !r.DepartAt.IsZero() && !r.ArriveAt.IsZero() {
+		return nil, errors.New("InvalidArgument, only one of departure time and arrival time can be set")
+	}
+	if !r.DepartAt.IsZero() {
+		values.Add("departureTime", formatTime(r.DepartAt))
+	}
+	if !r.ArriveAt.IsZero() {
+		values.Add("arrivalTime", formatTime(r.ArriveAt))
+	}
+	if len(r.Modes) > 0 && len(r.ExcludeModes) > 0 {
+		return nil, errors.New("InvalidArgument, only one of modes and exclude modes can be set")
+	}
+	modes := make([]string, 0, len(r.Modes)+len(r.ExcludeModes))
+	for _, mode := range r.Modes {
+		m := mode.String()
+		if m == invalid || m == unspecified {
+			return nil, fmt.Errorf("invalid transit mode")
+		}
+		modes = append(modes, m)
+	}
+	for _, mode := range r.ExcludeModes {
+		m := mode.String()
+		if m == invalid || m == unspecified {
+			return nil, fmt.Errorf("invalid transit mode")
+		}
+		modes = append(modes, "-"+m)
+	}
+	if len(modes) > 0 {
+		values.Add("modes", strings.Join(modes, ","))
+	}
+	if r.MaxChanges != nil {
+		if *r.MaxChanges < 0 {
+			return nil, fmt.Errorf("InvalidArgument, invalid max changes %d", *r.MaxChanges)
+		}
+		values.Add("changes", strconv.Itoa(*r.MaxChanges))
+	}
+	if r.Alternatives < 0 || r.Alternatives > maxAlternatives {
+		return nil, fmt.Errorf("invalid alternatives %d, must be between 0 and %d", r.Alternatives, maxAlternatives)
+	}
+	if r.Alternatives > 0 {
+		values.Add("alternatives", strconv.Itoa(r.Alternatives))
+	}
+	if r.Lang != "" {
+		values.Add("lang", r.Lang)
+	}
+	returns := make([]string, 0, len(r.Return))
+	for _, attribute := range r.Return {
+		returns = append(returns, string(attribute))
+	}
+	if len(returns) == 0 {
+		returns = append(returns, string(TravelSummaryReturnAttribute))
+	}
+	values.Add("return", strings.Join(returns, ","))
+	if r.PedestrianSpeed > 0 {
+		values.Add("pedestrian[speed]", formatFloat(r.PedestrianSpeed))
+	}
+	if r.PedestrianMaxDistance > 0 {
+		values.Add("pedestrian[maxDistance]", strconv.Itoa(r.PedestrianMaxDistance))
+	}
+	return values, nil
+}
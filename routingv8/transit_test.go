@@ -0,0 +1,166 @@
+package routingv8_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestTransitService_Routes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := FileMock{responseFile: "transit-route.json"}
+	routingClient := routingv8.NewClient(&client)
+	maxChanges := 2
+	got, err := routingClient.Transit.Routes(ctx, &routingv8.TransitRoutesRequest{
+		Origin:      routingv8.GeoWaypoint{Lat: 57.70887, Long: 11.97456},
+		Destination: routingv8.GeoWaypoint{Lat: 57.79655, Long: 12.04962},
+		DepartAt:    time.Date(2021, 11, 1, 16, 0, 0, 0, time.UTC),
+		ExcludeModes: []routingv8.TransitMode{
+			routingv8.TransitModeFerry,
+			routingv8.TransitModeFlight,
+		},
+		MaxChanges: &maxChanges,
+		Return: []routingv8.ReturnAttribute{
+			routingv8.TravelSummaryReturnAttribute,
+			routingv8.IntermediateReturnAttribute,
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, "/v8/routes", client.requestPath)
+	assert.Equal(
+		t,
+		"changes=2&departureTime=2021-11-01T16%3A00%3A00Z&destination=57.79655%2C12.04962"+
+			"&modes=-ferry%2C-flight&origin=57.70887%2C11.97456&return=travelSummary%2Cintermediate",
+		client.requestRawQuery,
+	)
+	assert.Equal(t, 1, len(got.Routes))
+	assert.Equal(t, 2, len(got.Routes[0].Sections))
+	walk, transit := got.Routes[0].Sections[0], got.Routes[0].Sections[1]
+	assert.Equal(t, "pedestrian", walk.Transport.Mode)
+	assert.Equal(t, routingv8.Summary{Duration: 300, Length: 350}, walk.TravelSummary)
+	centralstationen := routingv8.Place{
+		Type:                 routingv8.PlaceTypeStation,
+		ID:                   "740025616",
+		Name:                 "Centralstationen",
+		Location:             routingv8.GeoWaypoint{Lat: 57.70966, Long: 11.97322},
+		Code:                 "CEN",
+		Platform:             "A",
+		WheelchairAccessible: "yes",
+	}
+	assert.DeepEqual(t, centralstationen, walk.Arrival.Place)
+	assert.DeepEqual(t, centralstationen, transit.Departure.Place)
+	assert.Equal(t, int32(60), transit.Departure.Delay)
+	assert.DeepEqual(t, routingv8.Transport{
+		Mode:      "lightRail",
+		Name:      "9",
+		Category:  "Tram",
+		Color:     "#7ED8F6",
+		TextColor: "#000000",
+		Headsign:  "Angered Centrum",
+		ShortName: "9",
+		LongName:  "Kungssten - Angered Centrum",
+	}, transit.Transport)
+	assert.DeepEqual(t, &routingv8.Agency{
+		ID:      "vt",
+		Name:    "Västtrafik",
+		Website: "https://www.vasttrafik.se",
+	}, transit.Agency)
+	assert.Equal(t, 1, len(transit.IntermediateStops))
+	assert.Equal(t, "Gamlestads Torg", transit.IntermediateStops[0].Departure.Place.Name)
+	assert.Equal(t, int32(60), transit.IntermediateStops[0].Duration)
+}
+
+func TestTransitService_Routes_QueryParams(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	origin := routingv8.GeoWaypoint{Lat: 57.70887, Long: 11.97456}
+	destination := routingv8.GeoWaypoint{Lat: 57.79655, Long: 12.04962}
+	negative := -1
+	for _, tt := range []struct {
+		name     string
+		request  *routingv8.TransitRoutesRequest
+		expected string
+		errStr   string
+	}{
+		{
+			name: "defaults",
+			request: &routingv8.TransitRoutesRequest{
+				Origin:      origin,
+				Destination: destination,
+			},
+			expected: "destination=57.79655%2C12.04962&origin=57.70887%2C11.97456&return=travelSummary",
+		},
+		{
+			name: "with arrival time, modes and pedestrian options",
+			request: &routingv8.TransitRoutesRequest{
+				Origin:                origin,
+				Destination:           destination,
+				ArriveAt:              time.Date(2021, 11, 1, 18, 0, 0, 0, time.UTC),
+				Modes:                 []routingv8.TransitMode{routingv8.TransitModeBus, routingv8.TransitModeLightRail},
+				Alternatives:          2,
+				Lang:                  "sv-SE",
+				PedestrianSpeed:       1.2,
+				PedestrianMaxDistance: 800,
+			},
+			expected: "alternatives=2&arrivalTime=2021-11-01T18%3A00%3A00Z&destination=57.79655%2C12.04962" +
+				"&lang=sv-SE&modes=bus%2ClightRail&origin=57.70887%2C11.97456" +
+				"&pedestrian%5BmaxDistance%5D=800&pedestrian%5Bspeed%5D=1.2&return=travelSummary",
+		},
+		{
+			name: "with departure and arrival time",
+			request: &routingv8.TransitRoutesRequest{
+				Origin:      origin,
+				Destination: destination,
+				DepartAt:    time.Date(2021, 11, 1, 16, 0, 0, 0, time.UTC),
+				ArriveAt:    time.Date(2021, 11, 1, 18, 0, 0, 0, time.UTC),
+			},
+			errStr: "only one of departure time and arrival time can be set",
+		},
+		{
+			name: "with modes and exclude modes",
+			request: &routingv8.TransitRoutesRequest{
+				Origin:       origin,
+				Destination:  destination,
+				Modes:        []routingv8.TransitMode{routingv8.TransitModeBus},
+				ExcludeModes: []routingv8.TransitMode{routingv8.TransitModeFerry},
+			},
+			errStr: "only one of modes and exclude modes can be set",
+		},
+		{
+			name: "with invalid mode",
+			request: &routingv8.TransitRoutesRequest{
+				Origin:      origin,
+				Destination: destination,
+				Modes:       []routingv8.TransitMode{42},
+			},
+			errStr: "invalid transit mode",
+		},
+		{
+			name: "with negative max changes",
+			request: &routingv8.TransitRoutesRequest{
+				Origin:      origin,
+				Destination: destination,
+				MaxChanges:  &negative,
+			},
+			errStr: "invalid max changes -1",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := FileMock{responseFile: "transit-route.json"}
+			routingClient := routingv8.NewClient(&client)
+			_, err := routingClient.Transit.Routes(ctx, tt.request)
+			if tt.errStr != "" {
+				assert.ErrorContains(t, err, tt.errStr)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.Equal(t, tt.expected, client.requestRawQuery)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package routingv8
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Waypoint is a via waypoint with options for how it is matched to the road network and how it is visited.
+type Waypoint struct {
+	// Location of the waypoint.
+	Location GeoWaypoint
+	// StopDuration is the time spent at the waypoint, which is included in the departure time of the next section.
+	// Rounded down to whole seconds.
+	StopDuration time.Duration
+	// PassThrough makes the route pass through the waypoint without stopping, i.e. it does not split the route
+	// into sections. Mutually exclusive with StopDuration.
+	PassThrough bool
+	// Course is the heading of the vehicle at the waypoint, in degrees clockwise from north between 0 and 360.
+	Course *int
+	// SideOfStreetHint is a location on the side of the street where the waypoint should be reached, e.g. a
+	// loading dock.
+	SideOfStreetHint *GeoWaypoint
+	// MatchSideOfStreet defines when the SideOfStreetHint is applied. Requires SideOfStreetHint.
+	MatchSideOfStreet MatchSideOfStreet
+	// Radius in meters of the area around the location where the waypoint may be matched.
+	Radius int
+	// NameHint is the name of the street the waypoint should be matched to.
+	NameHint string
+}
+
+type MatchSideOfStreet int
+
+const (
+	MatchSideOfStreetUnspecified MatchSideOfStreet = iota
+	// MatchSideOfStreetAlways always prefers the side of street of the SideOfStreetHint.
+	MatchSideOfStreetAlways
+	// MatchSideOfStreetOnlyIfDivided only prefers the side of street of the SideOfStreetHint on divided roads.
+	MatchSideOfStreetOnlyIfDivided
+)
+
+func (m *MatchSideOfStreet) String() string {
+	switch *m {
+	case MatchSideOfStreetUnspecified:
+		return unspecified
+	case MatchSideOfStreetAlways:
+		return "always"
+	case MatchSideOfStreetOnlyIfDivided:
+		return "onlyIfDivided"
+	default:
+		return invalid
+	}
+}
+
+// queryString returns the waypoint in the format {lat},{lng}[;placeOptions][!waypointOptions].
+func (w *Waypoint) queryString() (string, error) {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%v,%v", w.Location.Lat, w.Location.Long))
+	if w.Course != nil {
+		if *w.Course < 0 || *w.Course > 360 {
+			return "", fmt.Errorf("invalid course %d, must be between 0 and 360", *w.Course)
+		}
+		b.WriteString(fmt.Sprintf(";course=%d", *w.Course))
+	}
+	if w.SideOfStreetHint != nil {
+		b.WriteString(fmt.Sprintf(";sideOfStreetHint=%v,%v", w.SideOfStreetHint.Lat, w.SideOfStreetHint.Long))
+	}
+	m := w.MatchSideOfStreet.String()
+	if m == invalid {
+		return "", fmt.Errorf("invalid match side of street")
+	}
+	if m != unspecified {
+		if w.SideOfStreetHint == nil {
+			return "", fmt.Errorf("match side of street requires a side of street hint")
+		}
+		b.WriteString(";matchSideOfStreet=" + m)
+	}
+	if w.Radius < 0 {
+		return "", fmt.Errorf("invalid radius %d", w.Radius)
+	}
+	if w.Radius > 0 {
+		b.WriteString(fmt.Sprintf(";radius=%d", w.Radius))
+	}
+	if w.NameHint != "" {
+		if strings.ContainsAny(w.NameHint, ";!") {
+			return "", fmt.Errorf("invalid name hint %q, must not contain ';' or '!'", w.NameHint)
+		}
+		b.WriteString(";nameHint=" + w.NameHint)
+	}
+	if w.StopDuration < 0 {
+		return "", fmt.Errorf("invalid stop duration %v", w.StopDuration)
+	}
+	if w.PassThrough && w.StopDuration > 0 {
+		return "", fmt.Errorf("a pass through waypoint can not have a stop duration")
+	}
+	if w.StopDuration > 0 {
+		b.WriteString(fmt.Sprintf("!stopDuration=%d", int64(w.StopDuration/time.Second)))
+	}
+	if w.PassThrough {
+		b.WriteString("!passThrough=true")
+	}
+	return b.String(), nil
+}